@@ -0,0 +1,218 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// BackpressurePolicy controls what an AsyncWriter does when its internal
+// buffer is full and a new record arrives.
+type BackpressurePolicy int
+
+const (
+	// DropOldest discards the oldest buffered record to make room.
+	DropOldest BackpressurePolicy = iota
+	// DropNewest discards the incoming record, leaving the buffer untouched.
+	DropNewest
+	// Block waits indefinitely for room in the buffer.
+	Block
+	// BlockWithTimeout waits up to AsyncWriterOptions.BlockTimeout for room,
+	// then falls back to DropNewest behavior.
+	BlockWithTimeout
+)
+
+// AsyncWriterOptions configures an AsyncWriter.
+type AsyncWriterOptions struct {
+	BufferSize    int                // ring buffer capacity in records (default 1024)
+	FlushInterval time.Duration      // idle tick of the background loop (default 100ms); records are written one at a time as soon as they are dequeued, so it neither delays nor batches delivery
+	Policy        BackpressurePolicy // default DropOldest
+	BlockTimeout  time.Duration      // used only when Policy == BlockWithTimeout
+	// CloseTimeout bounds how long Close waits for the queue to drain before
+	// giving up and closing the underlying writer anyway (default: wait
+	// indefinitely).
+	CloseTimeout time.Duration
+}
+
+// AsyncWriter wraps an io.Writer so that writes are queued and delivered by a
+// background goroutine instead of blocking the caller on I/O.
+type AsyncWriter struct {
+	target io.Writer
+	opts   AsyncWriterOptions
+
+	queue    chan []byte
+	stopC    chan struct{}
+	stoppedC chan struct{}
+	once     sync.Once
+
+	mu      sync.Mutex
+	dropped int64
+}
+
+// NewAsyncWriter creates an AsyncWriter around w and starts its background
+// delivery goroutine.
+func NewAsyncWriter(w io.Writer, opts AsyncWriterOptions) *AsyncWriter {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 1024
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = 100 * time.Millisecond
+	}
+
+	aw := &AsyncWriter{
+		target:   w,
+		opts:     opts,
+		queue:    make(chan []byte, opts.BufferSize),
+		stopC:    make(chan struct{}),
+		stoppedC: make(chan struct{}),
+	}
+	go aw.loop()
+	return aw
+}
+
+// Write enqueues data for asynchronous delivery. It never blocks on the
+// underlying writer's I/O. When the buffer is full, the configured
+// BackpressurePolicy decides: Block waits for room indefinitely,
+// BlockWithTimeout waits up to BlockTimeout, and DropOldest/DropNewest
+// return without waiting.
+func (aw *AsyncWriter) Write(data []byte) (int, error) {
+	select {
+	case <-aw.stopC:
+		return 0, fmt.Errorf("logger: async writer closed")
+	default:
+	}
+
+	buf := append([]byte(nil), data...)
+
+	switch aw.opts.Policy {
+	case Block:
+		select {
+		case aw.queue <- buf:
+		case <-aw.stopC:
+			return 0, fmt.Errorf("logger: async writer closed")
+		}
+	case BlockWithTimeout:
+		timer := time.NewTimer(aw.opts.BlockTimeout)
+		defer timer.Stop()
+		select {
+		case aw.queue <- buf:
+		case <-timer.C:
+			aw.incDropped()
+		case <-aw.stopC:
+			return 0, fmt.Errorf("logger: async writer closed")
+		}
+	case DropOldest:
+		select {
+		case aw.queue <- buf:
+		default:
+			select {
+			case <-aw.queue:
+				aw.incDropped()
+			default:
+			}
+			select {
+			case aw.queue <- buf:
+			default:
+				aw.incDropped()
+			}
+		}
+	default: // DropNewest
+		select {
+		case aw.queue <- buf:
+		default:
+			aw.incDropped()
+		}
+	}
+
+	return len(data), nil
+}
+
+func (aw *AsyncWriter) incDropped() {
+	aw.mu.Lock()
+	aw.dropped++
+	aw.mu.Unlock()
+
+	if metrics != nil {
+		metrics.IncDropped("async_full")
+	}
+}
+
+// Flush synchronously writes every record currently queued, bypassing the
+// background goroutine. Used to give a record (e.g. a Fatal log) a
+// synchronous write while preserving the ordering of anything queued ahead
+// of it.
+func (aw *AsyncWriter) Flush() {
+	for {
+		select {
+		case buf := <-aw.queue:
+			_, _ = aw.target.Write(buf)
+		default:
+			return
+		}
+	}
+}
+
+// Dropped returns the number of records dropped due to backpressure so far.
+func (aw *AsyncWriter) Dropped() int64 {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+	return aw.dropped
+}
+
+func (aw *AsyncWriter) loop() {
+	defer close(aw.stoppedC)
+
+	ticker := time.NewTicker(aw.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case buf := <-aw.queue:
+			_, _ = aw.target.Write(buf)
+		case <-ticker.C:
+			// Records are written as soon as they're dequeued, so there is
+			// nothing batched left to flush on tick; this just keeps the
+			// loop alive on an idle queue.
+		case <-aw.stopC:
+			aw.drain()
+			return
+		}
+	}
+}
+
+func (aw *AsyncWriter) drain() {
+	for {
+		select {
+		case buf := <-aw.queue:
+			_, _ = aw.target.Write(buf)
+		default:
+			return
+		}
+	}
+}
+
+// Close stops the background goroutine after draining any queued records,
+// then closes the underlying writer if it implements io.Closer. If
+// CloseTimeout is set, Close gives up waiting on the drain (leaving any
+// remaining records unwritten) once it elapses, so a stuck sink can't hang
+// process shutdown forever.
+func (aw *AsyncWriter) Close() error {
+	aw.once.Do(func() { close(aw.stopC) })
+
+	if aw.opts.CloseTimeout > 0 {
+		timer := time.NewTimer(aw.opts.CloseTimeout)
+		defer timer.Stop()
+		select {
+		case <-aw.stoppedC:
+		case <-timer.C:
+		}
+	} else {
+		<-aw.stoppedC
+	}
+
+	if closer, ok := aw.target.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -16,14 +16,19 @@ const (
 	KeyCorrelationID = "correlationId"
 	KeyRequestID     = "requestId"
 	KeyTraceID       = "traceId"
+	KeySpanID        = "spanId"
 	KeyNamespace     = "namespace"
 	KeyService       = "service"
 	KeyDuration      = "duration"
 	KeyError         = "error"
 	KeyErrorDetails  = "errorDetails"
-	KeyContext        = "context"
+	KeyContext       = "context"
 	KeyUser          = "user"
 	KeyHTTP          = "http"
+	// KeySampledSince is attached by BurstSampler to the first record
+	// allowed through after one or more at the same (level, message) were
+	// suppressed, reporting how many were dropped in between.
+	KeySampledSince = "sampled_since"
 )
 
 // Map is the type used for structured context data.
@@ -75,7 +80,7 @@ type TelemetryFields struct {
 // of the TypeScript, Python, and Rust SDKs.
 var (
 	globalContext Map
-	contextMu    sync.RWMutex
+	contextMu     sync.RWMutex
 )
 
 func init() {
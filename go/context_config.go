@@ -12,14 +12,18 @@ const (
 	ConfigOnlyKeys
 	// ConfigNested applies nested configuration rules to object children.
 	ConfigNested
+	// ConfigRedact runs a Redactor over string values found at this node,
+	// recursing into nested maps, instead of removing the branch entirely.
+	ConfigRedact
 )
 
 // ContextConfig defines how to filter context data in log output.
 // It forms a tree structure that can recursively filter nested maps.
 type ContextConfig struct {
 	Type     ContextConfigType
-	Keys     []string                   // For ConfigOnlyKeys
-	Children map[string]*ContextConfig  // For ConfigNested
+	Keys     []string                  // For ConfigOnlyKeys
+	Children map[string]*ContextConfig // For ConfigNested
+	Redactor Redactor                  // For ConfigRedact
 }
 
 // AllowAll returns a config that includes everything.
@@ -43,6 +47,12 @@ func Nested(children map[string]*ContextConfig) *ContextConfig {
 	return &ContextConfig{Type: ConfigNested, Children: children}
 }
 
+// Redact returns a config that runs redactor over every string value found
+// at this node (recursing into nested maps) instead of removing it.
+func Redact(redactor Redactor) *ContextConfig {
+	return &ContextConfig{Type: ConfigRedact, Redactor: redactor}
+}
+
 // PresetConfigMinimal filters HTTP context to essential fields only,
 // matching the Rust CONFIG_MINIMAL / TypeScript configMinimal behavior.
 var PresetConfigMinimal = Nested(map[string]*ContextConfig{
@@ -55,6 +65,26 @@ var PresetConfigMinimal = Nested(map[string]*ContextConfig{
 // PresetConfigFull allows all context through unfiltered.
 var PresetConfigFull = AllowAll()
 
+// PresetConfigRedactSecrets filters http.response the way PresetConfigMinimal
+// does (statusCode and headers only), but keeps every http.request field
+// rather than PresetConfigMinimal's short list. It runs DefaultSecretsRedactor
+// over the string values of the request body and of the "authorization" and
+// "cookie" request headers. Those headers are matched by exact lowercase key,
+// and only when the headers value is a nested Map; headers held as a
+// map[string]string (the type of HTTPRequest.Headers) pass through unredacted.
+var PresetConfigRedactSecrets = Nested(map[string]*ContextConfig{
+	"http": Nested(map[string]*ContextConfig{
+		"request": Nested(map[string]*ContextConfig{
+			"headers": Nested(map[string]*ContextConfig{
+				"authorization": Redact(DefaultSecretsRedactor),
+				"cookie":        Redact(DefaultSecretsRedactor),
+			}),
+			"body": Redact(DefaultSecretsRedactor),
+		}),
+		"response": OnlyKeys("statusCode", "headers"),
+	}),
+})
+
 // ApplyContextConfig recursively filters a map based on the config.
 // It returns a new map with the filtered result, leaving the original untouched.
 func ApplyContextConfig(data Map, config *ContextConfig) Map {
@@ -78,6 +108,9 @@ func ApplyContextConfig(data Map, config *ContextConfig) Map {
 		}
 		return filtered
 
+	case ConfigRedact:
+		return redactMap(data, config.Redactor)
+
 	case ConfigNested:
 		filtered := make(Map, len(data))
 		for key, val := range data {
@@ -95,10 +128,16 @@ func ApplyContextConfig(data Map, config *ContextConfig) Map {
 					filtered[key] = result
 				}
 			} else {
-				// Non-map values: apply Deny/AllowAll directly
+				// Non-map values: apply Deny/Redact/AllowAll directly
 				switch childConfig.Type {
 				case ConfigDeny:
 					// Skip this key
+				case ConfigRedact:
+					if strVal, ok := val.(string); ok && childConfig.Redactor != nil {
+						filtered[key] = childConfig.Redactor(strVal)
+					} else {
+						filtered[key] = val
+					}
 				default:
 					filtered[key] = val
 				}
@@ -110,3 +149,24 @@ func ApplyContextConfig(data Map, config *ContextConfig) Map {
 		return data
 	}
 }
+
+// redactMap returns a copy of data with redactor applied to every string
+// value, recursing into nested maps. The original map is left untouched.
+func redactMap(data Map, redactor Redactor) Map {
+	result := make(Map, len(data))
+	for key, val := range data {
+		switch v := val.(type) {
+		case string:
+			if redactor != nil {
+				result[key] = redactor(v)
+			} else {
+				result[key] = v
+			}
+		case Map:
+			result[key] = redactMap(v, redactor)
+		default:
+			result[key] = v
+		}
+	}
+	return result
+}
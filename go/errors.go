@@ -0,0 +1,170 @@
+package logger
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+// defaultStackSkipPrefixes elides frames that are never useful in a stack
+// trace attached to a log record: Go's runtime and testing machinery, and
+// the logger's own internal frames that sit between the caller and the
+// point where the stack was captured. This intentionally names specific
+// internal functions rather than the whole module's import path, since the
+// latter would also match (and blank out) the stacks of callers whose own
+// code happens to live under github.com/SmooAI/logger/go, including this
+// package's own tests.
+var defaultStackSkipPrefixes = []string{
+	"runtime.",
+	"testing.",
+	"github.com/SmooAI/logger/go.WrapError",
+}
+
+// wrappedError annotates an error with a snapshot of the call stack at the
+// point WrapError was called, so that errors which don't otherwise carry a
+// stack trace (e.g. plain fmt.Errorf chains) can still surface one.
+type wrappedError struct {
+	err error
+	pcs []uintptr
+}
+
+// WrapError wraps err with a snapshot of the current call stack (via
+// runtime.Callers). The wrapped error's Error() and Unwrap() delegate to
+// err, so it composes with fmt.Errorf("%w", ...) and errors.Is/As as usual;
+// the logger's CaptureStacks option uses the snapshot to populate
+// errorDetails[].stack. Returns nil if err is nil.
+func WrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var pcs [64]uintptr
+	n := runtime.Callers(2, pcs[:])
+	return &wrappedError{err: err, pcs: append([]uintptr(nil), pcs[:n]...)}
+}
+
+func (w *wrappedError) Error() string { return w.err.Error() }
+func (w *wrappedError) Unwrap() error { return w.err }
+
+// stackTracer matches pkg/errors' StackTrace() convention, letting the
+// logger pull frames out of errors created with pkgerrors.New/Wrap without
+// requiring callers to use WrapError.
+type stackTracer interface {
+	StackTrace() pkgerrors.StackTrace
+}
+
+// flattenErrorChain walks err's Unwrap() error / Unwrap() []error chain
+// (covering both plain %w wrapping and errors.Join trees) and returns one
+// ErrorDetail per distinct error, outermost first. A *wrappedError from
+// WrapError is transparent here: since its Error() text is identical to the
+// error it wraps, it contributes its captured stack to the wrapped error's
+// detail instead of a duplicate entry of its own.
+func (l *Logger) flattenErrorChain(err error) []ErrorDetail {
+	var details []ErrorDetail
+
+	var visit func(e error, stackOverride []CallerInfo)
+	visit = func(e error, stackOverride []CallerInfo) {
+		if e == nil {
+			return
+		}
+
+		if w, ok := e.(*wrappedError); ok {
+			var stack []CallerInfo
+			if l.captureStacks {
+				stack = l.filterFrames(framesFromPCs(w.pcs))
+			}
+			visit(w.err, stack)
+			return
+		}
+
+		detail := ErrorDetail{
+			Message: e.Error(),
+			Name:    fmt.Sprintf("%T", e),
+		}
+		if l.captureStacks {
+			if stackOverride != nil {
+				detail.Stack = stackOverride
+			} else {
+				detail.Stack = l.extractStack(e)
+			}
+		}
+		details = append(details, detail)
+
+		switch x := e.(type) {
+		case interface{ Unwrap() []error }:
+			for _, inner := range x.Unwrap() {
+				visit(inner, nil)
+			}
+		case interface{ Unwrap() error }:
+			visit(x.Unwrap(), nil)
+		}
+	}
+	visit(err, nil)
+
+	return details
+}
+
+// extractStack returns the stack frames attached to err, if any, either via
+// WrapError or pkg/errors' StackTrace(), filtered by l.stackSkipPackages and
+// defaultStackSkipPrefixes.
+func (l *Logger) extractStack(err error) []CallerInfo {
+	switch e := err.(type) {
+	case *wrappedError:
+		return l.filterFrames(framesFromPCs(e.pcs))
+	case stackTracer:
+		trace := e.StackTrace()
+		pcs := make([]uintptr, len(trace))
+		for i, f := range trace {
+			pcs[i] = uintptr(f)
+		}
+		return l.filterFrames(framesFromPCs(pcs))
+	default:
+		return nil
+	}
+}
+
+// framesFromPCs resolves a slice of program counters to CallerInfo frames.
+func framesFromPCs(pcs []uintptr) []CallerInfo {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	frames := runtime.CallersFrames(pcs)
+	out := make([]CallerInfo, 0, len(pcs))
+	for {
+		frame, more := frames.Next()
+		out = append(out, CallerInfo{
+			File:     filepath.Base(frame.File),
+			Line:     frame.Line,
+			Function: frame.Function,
+		})
+		if !more {
+			break
+		}
+	}
+	return out
+}
+
+// filterFrames drops frames matching defaultStackSkipPrefixes or
+// l.stackSkipPackages.
+func (l *Logger) filterFrames(frames []CallerInfo) []CallerInfo {
+	out := make([]CallerInfo, 0, len(frames))
+	for _, f := range frames {
+		if hasAnyPrefix(f.Function, defaultStackSkipPrefixes) || hasAnyPrefix(f.Function, l.stackSkipPackages) {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
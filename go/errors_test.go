@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+func TestFlattenErrorChainWalksFmtErrorfWrapping(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+
+	root := errors.New("root cause")
+	wrapped := fmt.Errorf("middle: %w", root)
+	outer := fmt.Errorf("outer: %w", wrapped)
+
+	details := l.flattenErrorChain(outer)
+	if len(details) != 3 {
+		t.Fatalf("got %d details, want 3", len(details))
+	}
+	if details[0].Message != outer.Error() {
+		t.Errorf("details[0].Message = %q, want %q", details[0].Message, outer.Error())
+	}
+	if details[1].Message != wrapped.Error() {
+		t.Errorf("details[1].Message = %q, want %q", details[1].Message, wrapped.Error())
+	}
+	if details[2].Message != "root cause" {
+		t.Errorf("details[2].Message = %q, want %q", details[2].Message, "root cause")
+	}
+}
+
+func TestFlattenErrorChainWalksErrorsJoin(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+
+	first := errors.New("first failure")
+	second := errors.New("second failure")
+	joined := errors.Join(first, second)
+
+	details := l.flattenErrorChain(joined)
+	if len(details) != 3 {
+		t.Fatalf("got %d details, want 3 (joined + 2 children)", len(details))
+	}
+	if details[1].Message != "first failure" || details[2].Message != "second failure" {
+		t.Errorf("details = %+v, want children in join order", details)
+	}
+}
+
+func TestCaptureStacksIncludesWrapErrorFrames(t *testing.T) {
+	resetGlobalContext()
+	var buf bytes.Buffer
+	l, err := New(Options{CaptureStacks: true})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	l.SetOutput(&buf)
+	l.prettyPrint = false
+
+	wrapped := WrapError(errors.New("boom"))
+	_ = l.Error("failed", wrapped)
+
+	var payload map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+
+	details, ok := payload[KeyErrorDetails].([]any)
+	if !ok || len(details) == 0 {
+		t.Fatal("errorDetails missing")
+	}
+	detail := details[0].(map[string]any)
+	stack, ok := detail["stack"].([]any)
+	if !ok || len(stack) == 0 {
+		t.Fatal("expected a non-empty stack array")
+	}
+	frame := stack[0].(map[string]any)
+	if frame["function"] == "" {
+		t.Error("frame.function should not be empty")
+	}
+}
+
+func TestCaptureStacksIncludesPkgErrorsStackTrace(t *testing.T) {
+	resetGlobalContext()
+	l, err := New(Options{CaptureStacks: true})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	pkgErr := pkgerrors.New("pkg/errors failure")
+	details := l.flattenErrorChain(pkgErr)
+	if len(details) != 1 {
+		t.Fatalf("got %d details, want 1", len(details))
+	}
+	if len(details[0].Stack) == 0 {
+		t.Error("expected pkg/errors StackTrace() to populate Stack")
+	}
+}
+
+func TestCaptureStacksDisabledByDefault(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+
+	details := l.flattenErrorChain(WrapError(errors.New("boom")))
+	if len(details) != 1 {
+		t.Fatalf("got %d details, want 1", len(details))
+	}
+	if details[0].Stack != nil {
+		t.Error("Stack should stay nil when CaptureStacks is not set")
+	}
+}
+
+func TestStackSkipPackagesElidesConfiguredFrames(t *testing.T) {
+	resetGlobalContext()
+	l, err := New(Options{CaptureStacks: true, StackSkipPackages: []string{"github.com/SmooAI/logger/go.TestStackSkipPackagesElidesConfiguredFrames"}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	details := l.flattenErrorChain(WrapError(errors.New("boom")))
+	for _, frame := range details[0].Stack {
+		if frame.Function == "github.com/SmooAI/logger/go.TestStackSkipPackagesElidesConfiguredFrames" {
+			t.Error("expected the configured frame to be elided")
+		}
+	}
+}
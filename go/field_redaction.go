@@ -0,0 +1,181 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+// FieldRedactor inspects one (path, value) pair from a record's merged
+// context map and returns the value to log in its place (unchanged, masked,
+// or hashed). path is the dotted walk from the payload root (e.g.
+// []string{"user", "email"}), so implementations can key off where a value
+// lives as well as what it looks like.
+//
+// Unlike ContextConfig's Redact node (see context_config.go), which only
+// scrubs string values within a path you've explicitly opted into,
+// installing a FieldRedactor via Logger.SetRedactor or the package-level
+// SetRedactor runs it over the *entire* merged payload automatically, right
+// before serialization.
+type FieldRedactor interface {
+	Redact(path []string, value any) any
+}
+
+// fieldRedactor is the process-wide default installed via SetRedactor. A
+// Logger with its own (via Logger.SetRedactor) uses that instead.
+var fieldRedactor FieldRedactor
+
+// SetRedactor installs the FieldRedactor run over every record's context
+// across all Loggers that haven't been given one of their own via
+// Logger.SetRedactor.
+func SetRedactor(r FieldRedactor) { fieldRedactor = r }
+
+// SetRedactor installs a FieldRedactor for this Logger only, taking
+// precedence over one installed process-wide via the package-level
+// SetRedactor.
+func (l *Logger) SetRedactor(r FieldRedactor) { l.redactor = r }
+
+// redactFields walks m recursively, replacing each leaf value with
+// r.Redact(path, value) and recursing into nested Map values under their own
+// path. It returns a new Map; m is left untouched.
+func redactFields(r FieldRedactor, path []string, m Map) Map {
+	out := make(Map, len(m))
+	for k, v := range m {
+		childPath := append(append([]string(nil), path...), k)
+		if nested, ok := v.(Map); ok {
+			out[k] = redactFields(r, childPath, nested)
+			continue
+		}
+		out[k] = r.Redact(childPath, v)
+	}
+	return out
+}
+
+// DefaultSensitiveKeys are key-name fragments DefaultFieldRedactor matches
+// against a value's final path segment, case-insensitively.
+var DefaultSensitiveKeys = []string{
+	"authorization",
+	"cookie",
+	"set-cookie",
+	"x-api-key",
+	"password",
+	"token",
+	"secret",
+	"ssn",
+}
+
+// RedactionOptions configures a DefaultFieldRedactor.
+type RedactionOptions struct {
+	// KeyPatterns are case-insensitive substrings matched against a value's
+	// final path segment; a match redacts the whole value regardless of its
+	// contents. Defaults to DefaultSensitiveKeys if nil.
+	KeyPatterns []string
+	// RedactEmails additionally masks email addresses found within string
+	// values that don't already match a KeyPattern.
+	RedactEmails bool
+	// HashRedacted replaces a matched value with
+	// "[REDACTED:sha256:<hex>]" instead of "[REDACTED]", so operators can
+	// still correlate repeated occurrences of the same secret without being
+	// able to recover it.
+	HashRedacted bool
+}
+
+// DefaultFieldRedactor is the default FieldRedactor: it redacts values whose
+// path's final segment matches a KeyPattern outright, and otherwise scrubs
+// JWT-shaped strings, AWS access keys, credit-card-like digit runs, and
+// (when RedactEmails is set) email addresses found within the value.
+type DefaultFieldRedactor struct {
+	opts          RedactionOptions
+	valueRedactor Redactor
+}
+
+// NewDefaultFieldRedactor creates a DefaultFieldRedactor from opts.
+func NewDefaultFieldRedactor(opts RedactionOptions) *DefaultFieldRedactor {
+	if opts.KeyPatterns == nil {
+		opts.KeyPatterns = DefaultSensitiveKeys
+	}
+
+	chain := []Redactor{RedactBearerTokens(""), RedactJWTs(""), RedactAWSAccessKeys(""), RedactCreditCards("")}
+	if opts.RedactEmails {
+		chain = append(chain, RedactEmails(""))
+	}
+
+	return &DefaultFieldRedactor{opts: opts, valueRedactor: ChainRedactors(chain...)}
+}
+
+// Redact implements FieldRedactor.
+func (d *DefaultFieldRedactor) Redact(path []string, value any) any {
+	if len(path) > 0 && d.matchesKey(path[len(path)-1]) {
+		return d.mask(fmt.Sprintf("%v", value))
+	}
+
+	if s, ok := value.(string); ok {
+		if redacted := d.valueRedactor(s); redacted != s {
+			return redacted
+		}
+	}
+	return value
+}
+
+func (d *DefaultFieldRedactor) matchesKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, pattern := range d.opts.KeyPatterns {
+		if strings.Contains(lower, strings.ToLower(pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *DefaultFieldRedactor) mask(value string) string {
+	if d.opts.HashRedacted {
+		sum := sha256.Sum256([]byte(value))
+		return fmt.Sprintf("[REDACTED:sha256:%x]", sum)
+	}
+	return defaultRedactMask
+}
+
+// pathFieldRedactor always redacts an explicit set of dotted paths,
+// regardless of key or value patterns. See WithRedactedFields.
+type pathFieldRedactor struct {
+	paths map[string]struct{}
+	mask  string
+}
+
+// WithRedactedFields returns a FieldRedactor that always redacts the value
+// at each given dotted path (e.g. "user.email", "http.headers.authorization"),
+// regardless of whether it would otherwise match a key or value pattern.
+// Paths are matched case-insensitively against the full dotted walk from the
+// payload root.
+func WithRedactedFields(paths ...string) FieldRedactor {
+	set := make(map[string]struct{}, len(paths))
+	for _, p := range paths {
+		set[strings.ToLower(p)] = struct{}{}
+	}
+	return &pathFieldRedactor{paths: set, mask: defaultRedactMask}
+}
+
+// Redact implements FieldRedactor.
+func (p *pathFieldRedactor) Redact(path []string, value any) any {
+	if _, ok := p.paths[strings.ToLower(strings.Join(path, "."))]; ok {
+		return p.mask
+	}
+	return value
+}
+
+// ChainFieldRedactors composes multiple FieldRedactors, threading the result
+// of one into the next. Useful for combining DefaultFieldRedactor's
+// pattern-based matching with an explicit WithRedactedFields allowlist.
+func ChainFieldRedactors(redactors ...FieldRedactor) FieldRedactor {
+	return fieldRedactorChain(redactors)
+}
+
+type fieldRedactorChain []FieldRedactor
+
+// Redact implements FieldRedactor.
+func (c fieldRedactorChain) Redact(path []string, value any) any {
+	for _, r := range c {
+		value = r.Redact(path, value)
+	}
+	return value
+}
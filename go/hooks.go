@@ -0,0 +1,404 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/syslog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Hook receives a copy of every record the logger emits that matches its
+// Levels, alongside the logger's normal stdout/file output. It is modeled on
+// the logrus hook pattern. Hooks fire after the logger's ContextFilter (if
+// any) has been applied, so a redacted payload is what leaves the process.
+//
+// A Fire error never bubbles up to the caller of Info/Warn/etc.; it is
+// counted instead and surfaced via Logger.HookStats.
+type Hook interface {
+	Fire(level Level, payload Map) error
+	Levels() []Level
+}
+
+type hookEntry struct {
+	hook     Hook
+	levels   map[Level]bool
+	failures int64
+}
+
+// hookState holds a Logger's registered hooks behind a mutex. It is stored
+// on Logger as a pointer so that WithContext/WithExtra's shallow `clone :=
+// *l` pattern copies the pointer rather than the mutex, sharing the same
+// hook registrations (and failure counters) across clones the way sinks and
+// other slice-backed state already does.
+type hookState struct {
+	mu    sync.RWMutex
+	hooks []*hookEntry
+}
+
+// AddHook registers h. Levels returning an empty slice means "fire for every
+// level".
+func (l *Logger) AddHook(h Hook) {
+	levels := make(map[Level]bool, len(h.Levels()))
+	for _, lvl := range h.Levels() {
+		levels[lvl] = true
+	}
+
+	l.hookState.mu.Lock()
+	defer l.hookState.mu.Unlock()
+	l.hookState.hooks = append(l.hookState.hooks, &hookEntry{hook: h, levels: levels})
+}
+
+// HookStat reports the Fire failure count for one registered hook.
+type HookStat struct {
+	Hook     Hook
+	Failures int64
+}
+
+// HookStats returns the current failure counts for each registered hook, in
+// registration order.
+func (l *Logger) HookStats() []HookStat {
+	l.hookState.mu.RLock()
+	defer l.hookState.mu.RUnlock()
+
+	stats := make([]HookStat, len(l.hookState.hooks))
+	for i, entry := range l.hookState.hooks {
+		stats[i] = HookStat{Hook: entry.hook, Failures: atomic.LoadInt64(&entry.failures)}
+	}
+	return stats
+}
+
+// fireHooks dispatches payload to every registered hook whose Levels allows
+// level, swallowing and counting any error instead of propagating it.
+func (l *Logger) fireHooks(level Level, payload Map) {
+	l.hookState.mu.RLock()
+	hooks := l.hookState.hooks
+	l.hookState.mu.RUnlock()
+
+	for _, entry := range hooks {
+		if len(entry.levels) > 0 && !entry.levels[level] {
+			continue
+		}
+		if err := entry.hook.Fire(level, payload); err != nil {
+			atomic.AddInt64(&entry.failures, 1)
+		}
+	}
+}
+
+// SyslogHook forwards records to the local syslog daemon via log/syslog,
+// mapping log Level to the closest syslog severity. For forwarding to a
+// remote syslog server instead of the local daemon, see SyslogSink in
+// sinks.go, which speaks RFC 5424 directly over a dialed connection.
+type SyslogHook struct {
+	writer *syslog.Writer
+	levels []Level
+}
+
+// NewSyslogHook dials local syslog with the given facility and process tag.
+// levels restricts which log levels are forwarded; nil forwards all of them.
+func NewSyslogHook(facility syslog.Priority, tag string, levels []Level) (*SyslogHook, error) {
+	w, err := syslog.New(facility, tag)
+	if err != nil {
+		return nil, fmt.Errorf("dial syslog: %w", err)
+	}
+	return &SyslogHook{writer: w, levels: levels}, nil
+}
+
+// Levels implements Hook.
+func (h *SyslogHook) Levels() []Level { return h.levels }
+
+// Fire implements Hook.
+func (h *SyslogHook) Fire(level Level, payload Map) error {
+	line := plainJSON(payload)
+	switch {
+	case level >= LevelFatal:
+		return h.writer.Crit(line)
+	case level >= LevelError:
+		return h.writer.Err(line)
+	case level >= LevelWarn:
+		return h.writer.Warning(line)
+	case level >= LevelInfo:
+		return h.writer.Info(line)
+	default:
+		return h.writer.Debug(line)
+	}
+}
+
+// Close closes the underlying syslog connection.
+func (h *SyslogHook) Close() error { return h.writer.Close() }
+
+// HTTPHookOptions configures an HTTPHook's batching, flush cadence, and
+// retry behavior. All fields are optional; zero values fall back to the
+// defaults noted below. It is read only at construction time, so unlike
+// fields once exported directly on HTTPHook, mutating a copy after
+// NewHTTPHook has no effect and races with nothing.
+type HTTPHookOptions struct {
+	// Client performs the HTTP requests. Defaults to http.DefaultClient.
+	Client *http.Client
+	// BatchSize flushes early once this many records have been buffered.
+	// Defaults to 100.
+	BatchSize int
+	// FlushInterval flushes whatever is buffered on a timer, regardless of
+	// BatchSize. Defaults to 5s.
+	FlushInterval time.Duration
+	// MaxRetries is the number of additional attempts after the first one.
+	// Defaults to 3.
+	MaxRetries int
+}
+
+// HTTPHook batches records and POSTs them as newline-delimited JSON to a URL,
+// retrying with exponential backoff. It is suitable for HTTP log intake such
+// as Loki, Vector, or Datadog.
+type HTTPHook struct {
+	url  string
+	opts HTTPHookOptions
+
+	levels []Level
+
+	mu    sync.Mutex
+	batch [][]byte
+
+	stopOnce sync.Once
+	stopC    chan struct{}
+	doneC    chan struct{}
+}
+
+// NewHTTPHook creates an HTTPHook posting to url and starts its background
+// flush loop. levels restricts which log levels are forwarded; nil forwards
+// all of them. Call Close to stop the flush loop and flush any remainder.
+func NewHTTPHook(url string, levels []Level, opts HTTPHookOptions) *HTTPHook {
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = 5 * time.Second
+	}
+	if opts.MaxRetries <= 0 {
+		opts.MaxRetries = 3
+	}
+
+	h := &HTTPHook{
+		url:    url,
+		opts:   opts,
+		levels: levels,
+		stopC:  make(chan struct{}),
+		doneC:  make(chan struct{}),
+	}
+	go h.loop()
+	return h
+}
+
+// Levels implements Hook.
+func (h *HTTPHook) Levels() []Level { return h.levels }
+
+// Fire implements Hook.
+func (h *HTTPHook) Fire(_ Level, payload Map) error {
+	line, err := MarshalJSON(payload)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	h.batch = append(h.batch, line)
+	full := len(h.batch) >= h.opts.BatchSize
+	h.mu.Unlock()
+
+	if full {
+		return h.flush()
+	}
+	return nil
+}
+
+func (h *HTTPHook) loop() {
+	defer close(h.doneC)
+
+	ticker := time.NewTicker(h.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = h.flush()
+		case <-h.stopC:
+			_ = h.flush()
+			return
+		}
+	}
+}
+
+func (h *HTTPHook) flush() error {
+	h.mu.Lock()
+	if len(h.batch) == 0 {
+		h.mu.Unlock()
+		return nil
+	}
+	batch := h.batch
+	h.batch = nil
+	h.mu.Unlock()
+
+	var body bytes.Buffer
+	for _, line := range batch {
+		body.Write(line)
+		body.WriteByte('\n')
+	}
+	payload := body.Bytes()
+
+	var lastErr error
+	for attempt := 0; attempt <= h.opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep((1 << uint(attempt-1)) * 100 * time.Millisecond)
+		}
+
+		req, err := http.NewRequest(http.MethodPost, h.url, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/x-ndjson")
+
+		resp, err := h.opts.Client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil
+		}
+		lastErr = fmt.Errorf("http hook: unexpected status %d from %s", resp.StatusCode, h.url)
+	}
+	return lastErr
+}
+
+// Close stops the background flush loop after flushing any buffered records.
+func (h *HTTPHook) Close() error {
+	h.stopOnce.Do(func() { close(h.stopC) })
+	<-h.doneC
+	return nil
+}
+
+// CloudWatchLogEvent is one record forwarded to CloudWatch Logs.
+type CloudWatchLogEvent struct {
+	// TimestampMillis is the event time in milliseconds since epoch.
+	TimestampMillis int64
+	Message         string
+}
+
+// CloudWatchPutter is the subset of the AWS CloudWatch Logs client
+// CloudWatchHook depends on. Callers wrap *cloudwatchlogs.Client (AWS SDK)
+// in an adapter satisfying this interface, keeping the AWS SDK out of this
+// module's dependency graph.
+type CloudWatchPutter interface {
+	PutLogEvents(ctx context.Context, logGroup, logStream string, events []CloudWatchLogEvent) error
+}
+
+// CloudWatchHookOptions configures a CloudWatchHook's flush cadence. It is
+// read only at construction time, so unlike a field once exported directly
+// on CloudWatchHook, mutating a copy after NewCloudWatchHook has no effect
+// and races with nothing.
+type CloudWatchHookOptions struct {
+	// FlushInterval flushes whatever is buffered on a timer. Defaults to 5s.
+	FlushInterval time.Duration
+}
+
+// CloudWatchHook coalesces records and forwards them to CloudWatch Logs via
+// PutLogEvents on each flush interval.
+type CloudWatchHook struct {
+	putter    CloudWatchPutter
+	logGroup  string
+	logStream string
+	opts      CloudWatchHookOptions
+
+	levels []Level
+
+	mu     sync.Mutex
+	events []CloudWatchLogEvent
+
+	stopOnce sync.Once
+	stopC    chan struct{}
+	doneC    chan struct{}
+}
+
+// NewCloudWatchHook creates a CloudWatchHook writing to logGroup/logStream
+// via putter and starts its background flush loop. levels restricts which
+// log levels are forwarded; nil forwards all of them. Call Close to stop the
+// flush loop and flush any remainder.
+func NewCloudWatchHook(putter CloudWatchPutter, logGroup, logStream string, levels []Level, opts CloudWatchHookOptions) *CloudWatchHook {
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = 5 * time.Second
+	}
+
+	h := &CloudWatchHook{
+		putter:    putter,
+		logGroup:  logGroup,
+		logStream: logStream,
+		opts:      opts,
+		levels:    levels,
+		stopC:     make(chan struct{}),
+		doneC:     make(chan struct{}),
+	}
+	go h.loop()
+	return h
+}
+
+// Levels implements Hook.
+func (h *CloudWatchHook) Levels() []Level { return h.levels }
+
+// Fire implements Hook.
+func (h *CloudWatchHook) Fire(_ Level, payload Map) error {
+	line, err := MarshalJSON(payload)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	h.events = append(h.events, CloudWatchLogEvent{
+		TimestampMillis: time.Now().UnixMilli(),
+		Message:         string(line),
+	})
+	h.mu.Unlock()
+	return nil
+}
+
+func (h *CloudWatchHook) loop() {
+	defer close(h.doneC)
+
+	ticker := time.NewTicker(h.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			_ = h.flush()
+		case <-h.stopC:
+			_ = h.flush()
+			return
+		}
+	}
+}
+
+func (h *CloudWatchHook) flush() error {
+	h.mu.Lock()
+	if len(h.events) == 0 {
+		h.mu.Unlock()
+		return nil
+	}
+	events := h.events
+	h.events = nil
+	h.mu.Unlock()
+
+	return h.putter.PutLogEvents(context.Background(), h.logGroup, h.logStream, events)
+}
+
+// Close stops the background flush loop after flushing any buffered events.
+func (h *CloudWatchHook) Close() error {
+	h.stopOnce.Do(func() { close(h.stopC) })
+	<-h.doneC
+	return nil
+}
@@ -0,0 +1,193 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type stubHook struct {
+	mu      sync.Mutex
+	levels  []Level
+	fired   []Map
+	failAll bool
+}
+
+func (h *stubHook) Levels() []Level { return h.levels }
+
+func (h *stubHook) Fire(_ Level, payload Map) error {
+	if h.failAll {
+		return errors.New("boom")
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fired = append(h.fired, payload)
+	return nil
+}
+
+func (h *stubHook) count() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.fired)
+}
+
+func TestAddHookFiresOnMatchingLevel(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+	hook := &stubHook{levels: []Level{LevelError}}
+	l.AddHook(hook)
+
+	_ = l.Info("ignored")
+	_ = l.Error("captured")
+
+	if got := hook.count(); got != 1 {
+		t.Fatalf("hook fired %d times, want 1", got)
+	}
+}
+
+func TestAddHookWithNoLevelsFiresForEverything(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+	hook := &stubHook{}
+	l.AddHook(hook)
+
+	_ = l.Info("a")
+	_ = l.Warn("b")
+
+	if got := hook.count(); got != 2 {
+		t.Fatalf("hook fired %d times, want 2", got)
+	}
+}
+
+func TestHookErrorsDoNotBubbleUpAndAreCounted(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+	hook := &stubHook{failAll: true}
+	l.AddHook(hook)
+
+	if err := l.Info("hi"); err != nil {
+		t.Fatalf("hook failure must not surface to the caller: %v", err)
+	}
+
+	stats := l.HookStats()
+	if len(stats) != 1 || stats[0].Failures != 1 {
+		t.Fatalf("HookStats = %+v, want one entry with 1 failure", stats)
+	}
+}
+
+func TestHooksFireAfterContextFilter(t *testing.T) {
+	resetGlobalContext()
+	l, err := New(Options{ContextFilter: Redact(RedactEmails("[EMAIL]"))})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	hook := &stubHook{}
+	l.AddHook(hook)
+
+	l.AddContext(Map{"contact": "jane@example.com"})
+	_ = l.Info("hi")
+
+	if hook.count() != 1 {
+		t.Fatalf("hook fired %d times, want 1", hook.count())
+	}
+	ctx, _ := hook.fired[0][KeyContext].(Map)
+	if ctx["contact"] != "[EMAIL]" {
+		t.Errorf("context.contact = %v, want redacted value to reach the hook", ctx["contact"])
+	}
+}
+
+func TestHTTPHookBatchesAndRetries(t *testing.T) {
+	var attempts int32
+	var received int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&attempts, 1)
+		if n == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		body := make([]byte, r.ContentLength)
+		_, _ = r.Body.Read(body)
+		atomic.AddInt32(&received, int32(strings.Count(string(body), "\n")))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	hook := NewHTTPHook(srv.URL, nil, HTTPHookOptions{FlushInterval: time.Hour, BatchSize: 2})
+
+	_ = hook.Fire(LevelInfo, Map{"msg": "one"})
+	_ = hook.Fire(LevelInfo, Map{"msg": "two"})
+
+	if err := hook.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if atomic.LoadInt32(&attempts) < 2 {
+		t.Errorf("attempts = %d, want at least 2 (one failure + one retry)", attempts)
+	}
+	if atomic.LoadInt32(&received) == 0 {
+		t.Error("expected the retried request to deliver the batched lines")
+	}
+}
+
+func TestHTTPHookLevelFilter(t *testing.T) {
+	resetGlobalContext()
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	l := Default()
+	hook := NewHTTPHook(srv.URL, []Level{LevelError}, HTTPHookOptions{BatchSize: 1, FlushInterval: time.Hour})
+	l.AddHook(hook)
+	defer hook.Close()
+
+	_ = l.Info("ignored")
+	_ = l.Error("captured")
+	_ = hook.Close()
+
+	if atomic.LoadInt32(&hits) != 1 {
+		t.Errorf("HTTP hook received %d requests, want 1", hits)
+	}
+}
+
+type stubCloudWatchPutter struct {
+	mu     sync.Mutex
+	events []CloudWatchLogEvent
+}
+
+func (p *stubCloudWatchPutter) PutLogEvents(_ context.Context, _, _ string, events []CloudWatchLogEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, events...)
+	return nil
+}
+
+func (p *stubCloudWatchPutter) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.events)
+}
+
+func TestCloudWatchHookCoalescesOnFlush(t *testing.T) {
+	putter := &stubCloudWatchPutter{}
+	hook := NewCloudWatchHook(putter, "my-group", "my-stream", nil, CloudWatchHookOptions{FlushInterval: time.Hour})
+
+	_ = hook.Fire(LevelInfo, Map{"msg": "one"})
+	_ = hook.Fire(LevelInfo, Map{"msg": "two"})
+
+	if err := hook.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if got := putter.count(); got != 2 {
+		t.Fatalf("putter received %d events, want 2", got)
+	}
+}
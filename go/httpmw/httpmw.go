@@ -0,0 +1,262 @@
+// Package httpmw provides net/http middleware equivalent to the Lambda
+// AddAPIGatewayContext integration for services that aren't behind API
+// Gateway: it scopes a Logger to each request (namespace, HTTP context,
+// correlation ID) via Logger.WithExtra rather than the shared global
+// context, so concurrent requests don't race each other the way Lambda's
+// one-invocation-at-a-time AddBaseContext calls can get away with. The
+// scoped Logger is attached to the request's context and is retrievable via
+// logger.FromRequest.
+package httpmw
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	logger "github.com/SmooAI/logger/go"
+)
+
+// traceparentPattern matches a W3C traceparent header value, mirroring the
+// core package's parseTraceparent (unexported there, so duplicated here
+// rather than pulled in as a dependency).
+var traceparentPattern = regexp.MustCompile(`^[0-9a-f]{2}-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}$`)
+
+// RoutePattern extracts the matched route pattern from a request, used to
+// build KeyNamespace as "METHOD pattern" instead of the raw, ID-bearing URL
+// path. The default checks the stdlib 1.22+ http.ServeMux pattern
+// (r.Pattern), falling back to r.URL.Path; install a different one via
+// WithRoutePattern for chi, gorilla/mux, or another router.
+type RoutePattern func(r *http.Request) string
+
+func defaultRoutePattern(r *http.Request) string {
+	if r.Pattern != "" {
+		return r.Pattern
+	}
+	return r.URL.Path
+}
+
+type config struct {
+	correlationHeaders []string
+	allowHeaders       map[string]bool
+	denyHeaders        map[string]bool
+	routePattern       RoutePattern
+	bodySampleMaxBytes int
+	promotedTrailers   []string
+}
+
+// Option configures Middleware.
+type Option func(*config)
+
+// WithCorrelationHeaders overrides the headers, checked in order, used to
+// derive a request's correlation ID. Defaults to X-Request-Id,
+// X-Correlation-Id, and traceparent. A request carrying none of these gets a
+// freshly generated correlation ID.
+func WithCorrelationHeaders(headers ...string) Option {
+	return func(c *config) { c.correlationHeaders = headers }
+}
+
+// WithAllowedHeaders restricts the headers copied into the logged HTTP
+// request context to this allowlist. Takes precedence over
+// WithDeniedHeaders when both are set.
+func WithAllowedHeaders(headers ...string) Option {
+	return func(c *config) { c.allowHeaders = toHeaderSet(headers) }
+}
+
+// WithDeniedHeaders drops these headers from the logged HTTP request
+// context; all others are kept.
+func WithDeniedHeaders(headers ...string) Option {
+	return func(c *config) { c.denyHeaders = toHeaderSet(headers) }
+}
+
+// WithRoutePattern overrides how the matched route pattern is extracted from
+// a request, for routers other than the stdlib 1.22+ http.ServeMux.
+func WithRoutePattern(fn RoutePattern) Option {
+	return func(c *config) { c.routePattern = fn }
+}
+
+// WithBodySampling enables capturing up to maxBytes of the request body into
+// the logged HTTP request context. The sampled bytes are spliced back onto
+// r.Body so the downstream handler still sees the full, unconsumed body.
+func WithBodySampling(maxBytes int) Option {
+	return func(c *config) { c.bodySampleMaxBytes = maxBytes }
+}
+
+// WithPromotedTrailers copies the named request trailer values, populated
+// once the handler has fully read the body, into the access-log record
+// under http.response.trailers.
+func WithPromotedTrailers(keys ...string) Option {
+	return func(c *config) { c.promotedTrailers = keys }
+}
+
+func toHeaderSet(headers []string) map[string]bool {
+	set := make(map[string]bool, len(headers))
+	for _, h := range headers {
+		set[strings.ToLower(h)] = true
+	}
+	return set
+}
+
+// Middleware returns net/http middleware that scopes l to each request (see
+// the package doc), stores the scoped Logger on the request's context, and
+// emits a single access-log record once the handler returns.
+func Middleware(l *logger.Logger, opts ...Option) func(http.Handler) http.Handler {
+	cfg := &config{
+		correlationHeaders: []string{"X-Request-Id", "X-Correlation-Id", "traceparent"},
+		routePattern:       defaultRoutePattern,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			correlationID, traceID := correlationFromHeaders(r, cfg.correlationHeaders)
+
+			reqMap := logger.Map{
+				"protocol": r.Proto,
+				"hostname": r.Host,
+				"path":     r.URL.Path,
+				"method":   r.Method,
+				"sourceIp": remoteIP(r),
+				"headers":  filterHeaders(r.Header, cfg.allowHeaders, cfg.denyHeaders),
+			}
+			if r.URL.RawQuery != "" {
+				reqMap["queryString"] = r.URL.RawQuery
+			}
+			if ua := r.UserAgent(); ua != "" {
+				reqMap["userAgent"] = ua
+			}
+			if cfg.bodySampleMaxBytes > 0 {
+				if body, ok := sampleBody(r, cfg.bodySampleMaxBytes); ok {
+					reqMap["body"] = body
+				}
+			}
+
+			httpMap := logger.Map{"request": reqMap}
+			extra := logger.Map{
+				logger.KeyNamespace:     strings.ToUpper(r.Method) + " " + cfg.routePattern(r),
+				logger.KeyCorrelationID: correlationID,
+				logger.KeyRequestID:     correlationID,
+				logger.KeyHTTP:          httpMap,
+			}
+			if traceID != "" {
+				extra[logger.KeyTraceID] = traceID
+			}
+
+			scoped := l.WithExtra(extra)
+			r = r.WithContext(logger.ContextWithLogger(r.Context(), scoped))
+
+			sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(sw, r)
+
+			respMap := logger.Map{
+				"statusCode": sw.status,
+				"bytes":      sw.bytes,
+				"durationMs": time.Since(start).Milliseconds(),
+			}
+			if len(cfg.promotedTrailers) > 0 {
+				if trailers := promotedTrailers(r, cfg.promotedTrailers); len(trailers) > 0 {
+					respMap["trailers"] = trailers
+				}
+			}
+			httpMap["response"] = respMap
+
+			_ = scoped.Info("request completed")
+		})
+	}
+}
+
+func correlationFromHeaders(r *http.Request, headers []string) (correlationID, traceID string) {
+	for _, name := range headers {
+		v := r.Header.Get(name)
+		if v == "" {
+			continue
+		}
+		if strings.EqualFold(name, "traceparent") {
+			if m := traceparentPattern.FindStringSubmatch(v); m != nil {
+				return m[1], m[1]
+			}
+			continue
+		}
+		return v, ""
+	}
+	return uuid.New().String(), ""
+}
+
+func filterHeaders(h http.Header, allow, deny map[string]bool) map[string]string {
+	out := make(map[string]string, len(h))
+	for key, values := range h {
+		lower := strings.ToLower(key)
+		if len(allow) > 0 {
+			if !allow[lower] {
+				continue
+			}
+		} else if deny[lower] {
+			continue
+		}
+		out[key] = strings.Join(values, ", ")
+	}
+	return out
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// sampleBody reads up to maxBytes of r.Body for logging, then restores the
+// full, unconsumed body for the downstream handler.
+func sampleBody(r *http.Request, maxBytes int) (string, bool) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return "", false
+	}
+	sampled, err := io.ReadAll(io.LimitReader(r.Body, int64(maxBytes)))
+	if err != nil {
+		return "", false
+	}
+	r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(sampled), r.Body))
+	if len(sampled) == 0 {
+		return "", false
+	}
+	return string(sampled), true
+}
+
+func promotedTrailers(r *http.Request, keys []string) logger.Map {
+	out := logger.Map{}
+	for _, key := range keys {
+		if v := r.Trailer.Get(key); v != "" {
+			out[key] = v
+		}
+	}
+	return out
+}
+
+// statusWriter wraps an http.ResponseWriter to capture the status code and
+// byte count written, for the final access-log record.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
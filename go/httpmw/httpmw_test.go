@@ -0,0 +1,165 @@
+package httpmw
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logger "github.com/SmooAI/logger/go"
+)
+
+func newTestLogger(buf *bytes.Buffer) *logger.Logger {
+	pretty := false
+	l, err := logger.New(logger.Options{PrettyPrint: &pretty})
+	if err != nil {
+		panic(err)
+	}
+	l.SetOutput(buf)
+	return l
+}
+
+func TestMiddlewareSetsNamespaceAndCorrelationID(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestLogger(&buf)
+
+	var got *logger.Logger
+	handler := Middleware(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = logger.FromRequest(r)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets/123", nil)
+	req.Header.Set("X-Correlation-Id", "corr-1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got == nil {
+		t.Fatal("handler should be able to retrieve the scoped Logger via logger.FromRequest")
+	}
+
+	_ = got.Info("inside handler")
+
+	var payload map[string]any
+	if err := json.Unmarshal(bytes.Split(buf.Bytes(), []byte("\n"))[0], &payload); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	if payload[logger.KeyNamespace] != "GET /widgets/123" {
+		t.Errorf("namespace = %v, want %q", payload[logger.KeyNamespace], "GET /widgets/123")
+	}
+	if payload[logger.KeyCorrelationID] != "corr-1" {
+		t.Errorf("correlationId = %v, want %q", payload[logger.KeyCorrelationID], "corr-1")
+	}
+}
+
+func TestMiddlewareGeneratesCorrelationIDWhenHeadersMissing(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestLogger(&buf)
+
+	var got *logger.Logger
+	handler := Middleware(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = logger.FromRequest(r)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	_ = got.Info("inside handler")
+	var payload map[string]any
+	if err := json.Unmarshal(bytes.Split(buf.Bytes(), []byte("\n"))[0], &payload); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	if payload[logger.KeyCorrelationID] == "" || payload[logger.KeyCorrelationID] == nil {
+		t.Error("expected a generated correlation ID when no header is present")
+	}
+}
+
+func TestMiddlewareLogsAccessRecordWithStatusAndBytes(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestLogger(&buf)
+
+	handler := Middleware(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("hello"))
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/items", nil))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	var payload map[string]any
+	if err := json.Unmarshal(lines[len(lines)-1], &payload); err != nil {
+		t.Fatalf("failed to parse access log line: %v", err)
+	}
+
+	httpCtx, ok := payload[logger.KeyHTTP].(map[string]any)
+	if !ok {
+		t.Fatalf("expected an %q field on the access log record", logger.KeyHTTP)
+	}
+	response, ok := httpCtx["response"].(map[string]any)
+	if !ok {
+		t.Fatal("expected http.response on the access log record")
+	}
+	if response["statusCode"] != float64(http.StatusCreated) {
+		t.Errorf("statusCode = %v, want %v", response["statusCode"], http.StatusCreated)
+	}
+	if response["bytes"] != float64(len("hello")) {
+		t.Errorf("bytes = %v, want %v", response["bytes"], len("hello"))
+	}
+}
+
+func TestMiddlewareDeniedHeadersAreDropped(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestLogger(&buf)
+
+	var got *logger.Logger
+	handler := Middleware(l, WithDeniedHeaders("Authorization"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = logger.FromRequest(r)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Accept", "application/json")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	_ = got.Info("inside handler")
+	var payload map[string]any
+	if err := json.Unmarshal(bytes.Split(buf.Bytes(), []byte("\n"))[0], &payload); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	headers := payload[logger.KeyHTTP].(map[string]any)["request"].(map[string]any)["headers"].(map[string]any)
+	if _, ok := headers["Authorization"]; ok {
+		t.Error("Authorization header should have been dropped by WithDeniedHeaders")
+	}
+	if _, ok := headers["Accept"]; !ok {
+		t.Error("Accept header should have been kept")
+	}
+}
+
+func TestMiddlewareDoesNotMutateGlobalContext(t *testing.T) {
+	var buf bytes.Buffer
+	l := newTestLogger(&buf)
+
+	handler := Middleware(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	req := httptest.NewRequest(http.MethodGet, "/scoped", nil)
+	req.Header.Set("X-Correlation-Id", "corr-scoped")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	buf.Reset()
+	_ = l.Info("outside middleware")
+	var payload map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	if payload[logger.KeyCorrelationID] == "corr-scoped" {
+		t.Error("the base Logger's global context should not pick up the request-scoped correlation ID")
+	}
+	if payload[logger.KeyNamespace] != nil {
+		t.Error("the base Logger's global context should not pick up the request-scoped namespace")
+	}
+}
+
+func TestDefaultRoutePatternFallsBackToURLPath(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/things/42", nil)
+	if got := defaultRoutePattern(req); got != "/things/42" {
+		t.Errorf("defaultRoutePattern = %q, want %q", got, "/things/42")
+	}
+}
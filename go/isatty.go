@@ -0,0 +1,24 @@
+package logger
+
+import "os"
+
+// isTerminal reports whether w is an *os.File connected to a character
+// device (a terminal), the same no-dependency heuristic the stdlib's own
+// tools use in place of a full isatty syscall wrapper.
+func isTerminal(w any) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// noColorEnv reports whether the NO_COLOR convention (https://no-color.org)
+// has been opted into via the environment.
+func noColorEnv() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
@@ -1,13 +1,24 @@
 package logger
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
+	"regexp"
+	"runtime/debug"
+	"strconv"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambdacontext"
 )
 
+// xrayTraceHeaderPattern matches the AWS X-Ray trace header the Lambda
+// runtime sets in the _X_AMZN_TRACE_ID environment variable for every
+// invocation, e.g. "Root=1-5e1b4151-5ac6c58dc39a5b4e2b6a6d2a;Parent=7df1b07be5b0c2b4;Sampled=1".
+var xrayTraceHeaderPattern = regexp.MustCompile(`Root=([^;]+);Parent=([^;]+);Sampled=(\d)`)
+
 // LambdaLogger wraps Logger with Lambda-specific context helpers.
 type LambdaLogger struct {
 	*Logger
@@ -44,6 +55,30 @@ func (l *LambdaLogger) AddLambdaContext(ctx context.Context) {
 	if l.CorrelationID() == "" || l.CorrelationID() == l.Context()[KeyRequestID] {
 		l.SetCorrelationID(lc.AwsRequestID)
 	}
+
+	l.addXRayTraceHeader(os.Getenv("_X_AMZN_TRACE_ID"))
+}
+
+// addXRayTraceHeader parses an AWS X-Ray trace header (format
+// "Root=1-<hex>-<hex>;Parent=<hex>;Sampled=1") and, when it parses, sets
+// KeyTraceID to the root trace ID and adds an "xray" sub-map with rootId,
+// parentId, and sampled, so records correlate with the X-Ray trace the
+// Lambda invocation belongs to.
+func (l *LambdaLogger) addXRayTraceHeader(header string) {
+	m := xrayTraceHeaderPattern.FindStringSubmatch(header)
+	if m == nil {
+		return
+	}
+	rootID, parentID, sampled := m[1], m[2], m[3] == "1"
+
+	l.AddBaseContextKey(KeyTraceID, rootID)
+	l.AddBaseContext(Map{
+		"xray": Map{
+			"rootId":   rootID,
+			"parentId": parentID,
+			"sampled":  sampled,
+		},
+	})
 }
 
 // GetLambdaEnvironmentContext returns Lambda environment variables as a context map.
@@ -84,8 +119,8 @@ func (l *LambdaLogger) AddLambdaEnvironmentContext() {
 // message ID, event source, event source ARN, and receipt handle.
 func (l *LambdaLogger) AddSQSRecordContext(record events.SQSMessage) {
 	sqsCtx := Map{
-		"messageId":     record.MessageId,
-		"eventSource":   record.EventSource,
+		"messageId":      record.MessageId,
+		"eventSource":    record.EventSource,
 		"eventSourceArn": record.EventSourceARN,
 	}
 
@@ -112,6 +147,204 @@ func (l *LambdaLogger) AddSQSRecordContext(record events.SQSMessage) {
 	}
 }
 
+// AddSNSRecordContext adds SNS notification context to the logger, including
+// message ID, topic ARN, and subject.
+func (l *LambdaLogger) AddSNSRecordContext(record events.SNSEventRecord) {
+	snsCtx := Map{
+		"messageId":            record.SNS.MessageID,
+		"topicArn":             record.SNS.TopicArn,
+		"eventSubscriptionArn": record.EventSubscriptionArn,
+	}
+	if record.SNS.Subject != "" {
+		snsCtx["subject"] = record.SNS.Subject
+	}
+
+	l.AddBaseContext(Map{
+		"sns": snsCtx,
+	})
+
+	if record.SNS.MessageID != "" {
+		l.SetCorrelationID(record.SNS.MessageID)
+	}
+}
+
+// AddEventBridgeContext adds EventBridge event context to the logger,
+// including the event ID, source, and detail type. CloudWatch Events and
+// EventBridge Scheduler invocations share this same event shape.
+func (l *LambdaLogger) AddEventBridgeContext(event events.CloudWatchEvent) {
+	l.addCloudWatchEventContext("eventBridge", event)
+}
+
+// AddCloudWatchEventContext adds CloudWatch Events/Scheduler context to the
+// logger. See AddEventBridgeContext for the identical EventBridge case.
+func (l *LambdaLogger) AddCloudWatchEventContext(event events.CloudWatchEvent) {
+	l.addCloudWatchEventContext("cloudWatchEvent", event)
+}
+
+func (l *LambdaLogger) addCloudWatchEventContext(key string, event events.CloudWatchEvent) {
+	l.AddBaseContext(Map{
+		key: Map{
+			"id":         event.ID,
+			"detailType": event.DetailType,
+			"source":     event.Source,
+		},
+	})
+
+	if event.DetailType != "" && event.ID != "" {
+		l.SetCorrelationID(event.DetailType + ":" + event.ID)
+	}
+}
+
+// AddKinesisRecordContext adds Kinesis stream record context to the logger,
+// including the sequence number and partition key.
+func (l *LambdaLogger) AddKinesisRecordContext(record events.KinesisEventRecord) {
+	l.AddBaseContext(Map{
+		"kinesis": Map{
+			"eventId":        record.EventID,
+			"eventSourceArn": record.EventSourceArn,
+			"partitionKey":   record.Kinesis.PartitionKey,
+			"sequenceNumber": record.Kinesis.SequenceNumber,
+			"data":           record.Kinesis.Data,
+		},
+	})
+
+	if record.Kinesis.SequenceNumber != "" {
+		l.SetCorrelationID(record.Kinesis.SequenceNumber)
+	}
+}
+
+// AddDynamoDBRecordContext adds DynamoDB Streams record context to the
+// logger, including the event name and sequence number.
+func (l *LambdaLogger) AddDynamoDBRecordContext(record events.DynamoDBEventRecord) {
+	l.AddBaseContext(Map{
+		"dynamodb": Map{
+			"eventId":        record.EventID,
+			"eventName":      record.EventName,
+			"eventSourceArn": record.EventSourceArn,
+			"sequenceNumber": record.Change.SequenceNumber,
+			"newImage":       record.Change.NewImage,
+			"oldImage":       record.Change.OldImage,
+		},
+	})
+}
+
+// AddS3RecordContext adds S3 event record context to the logger, including
+// the bucket, key, and the request ID S3 assigned the triggering operation.
+func (l *LambdaLogger) AddS3RecordContext(record events.S3EventRecord) {
+	l.AddBaseContext(Map{
+		"s3": Map{
+			"eventName": record.EventName,
+			"bucket":    record.S3.Bucket.Name,
+			"requestId": record.ResponseElements["x-amz-request-id"],
+			"object": Map{
+				"key":  record.S3.Object.Key,
+				"eTag": record.S3.Object.ETag,
+			},
+		},
+	})
+
+	if requestID := record.ResponseElements["x-amz-request-id"]; requestID != "" {
+		l.SetCorrelationID(requestID)
+	}
+}
+
+// AddALBContext adds Application Load Balancer request context to the
+// logger.
+func (l *LambdaLogger) AddALBContext(request events.ALBTargetGroupRequest) {
+	headers := make(map[string]string)
+	for k, v := range request.Headers {
+		headers[k] = v
+	}
+
+	l.AddHTTPRequest(HTTPRequest{
+		Method:  request.HTTPMethod,
+		Path:    request.Path,
+		Headers: headers,
+		Body:    request.Body,
+	})
+
+	l.AddBaseContext(Map{
+		"alb": Map{
+			"targetGroupArn": request.RequestContext.ELB.TargetGroupArn,
+		},
+	})
+
+	if traceID := headers["x-amzn-trace-id"]; traceID != "" {
+		l.SetCorrelationID(traceID)
+	}
+}
+
+// AddHTTPAPIContext adds API Gateway v2 (HTTP API) request context to the
+// logger.
+func (l *LambdaLogger) AddHTTPAPIContext(request events.APIGatewayV2HTTPRequest) {
+	headers := make(map[string]string)
+	for k, v := range request.Headers {
+		headers[k] = v
+	}
+
+	l.AddHTTPRequest(HTTPRequest{
+		Method:    request.RequestContext.HTTP.Method,
+		Path:      request.RequestContext.HTTP.Path,
+		SourceIP:  request.RequestContext.HTTP.SourceIP,
+		UserAgent: request.RequestContext.HTTP.UserAgent,
+		Headers:   headers,
+		Body:      request.Body,
+	})
+
+	httpApiCtx := Map{
+		"requestId": request.RequestContext.RequestID,
+		"stage":     request.RequestContext.Stage,
+		"apiId":     request.RequestContext.APIID,
+	}
+
+	l.AddBaseContext(Map{
+		"httpApi": httpApiCtx,
+	})
+
+	if request.RequestContext.RequestID != "" {
+		l.SetCorrelationID(request.RequestContext.RequestID)
+	}
+}
+
+// AddCognitoContext adds Cognito Sync trigger event context to the logger,
+// including the identity and dataset name.
+func (l *LambdaLogger) AddCognitoContext(event events.CognitoEvent) {
+	l.AddBaseContext(Map{
+		"cognito": Map{
+			"identityId":     event.IdentityID,
+			"identityPoolId": event.IdentityPoolID,
+			"datasetName":    event.DatasetName,
+			"eventType":      event.EventType,
+		},
+	})
+}
+
+// StepFunctionsContext identifies the Step Functions execution a Lambda task
+// state ran in. Step Functions has no dedicated Lambda event shape the way
+// SQS/SNS/etc. do; callers that thread this information through their task
+// input should extract it before calling AddStepFunctionsContext.
+type StepFunctionsContext struct {
+	ExecutionArn    string
+	StateMachineArn string
+	StateName       string
+}
+
+// AddStepFunctionsContext adds Step Functions execution context to the
+// logger.
+func (l *LambdaLogger) AddStepFunctionsContext(ctx StepFunctionsContext) {
+	l.AddBaseContext(Map{
+		"stepFunctions": Map{
+			"executionArn":    ctx.ExecutionArn,
+			"stateMachineArn": ctx.StateMachineArn,
+			"stateName":       ctx.StateName,
+		},
+	})
+
+	if ctx.ExecutionArn != "" {
+		l.SetCorrelationID(ctx.ExecutionArn)
+	}
+}
+
 // AddAPIGatewayContext adds API Gateway request context to the logger.
 func (l *LambdaLogger) AddAPIGatewayContext(request events.APIGatewayProxyRequest) {
 	headers := make(map[string]string)
@@ -146,7 +379,8 @@ func (l *LambdaLogger) AddAPIGatewayContext(request events.APIGatewayProxyReques
 
 // SlimDownLocally removes verbose context when running locally (IS_LOCAL env).
 // This keeps log output readable during local development by stripping
-// Lambda environment details, API Gateway metadata, and SQS receipt handles.
+// Lambda environment details, API Gateway metadata, SQS receipt handles,
+// Kinesis record payloads, DynamoDB stream images, and S3 object ETags.
 func (l *LambdaLogger) SlimDownLocally() {
 	if !IsLocal() {
 		return
@@ -166,5 +400,91 @@ func (l *LambdaLogger) SlimDownLocally() {
 	// Slim down API Gateway context
 	delete(ctx, "apiGateway")
 
+	// Kinesis records carry the base64-encoded payload in "data"; DynamoDB
+	// streams carry the full before/after item images. Both are verbose and
+	// rarely useful while iterating locally.
+	if kinesis, ok := ctx["kinesis"].(Map); ok {
+		delete(kinesis, "data")
+	}
+	if dynamodb, ok := ctx["dynamodb"].(Map); ok {
+		delete(dynamodb, "newImage")
+		delete(dynamodb, "oldImage")
+	}
+	if s3, ok := ctx["s3"].(Map); ok {
+		if object, ok := s3["object"].(Map); ok {
+			delete(object, "eTag")
+		}
+	}
+
 	l.SetContext(ctx)
 }
+
+// recoverPanic builds and emits a single FATAL record for a recovered panic,
+// then returns it as an error so the Lambda invocation is still recorded as
+// failed rather than crashing the process. The current lambda/apiGateway/sqs
+// context is attached automatically, since it's already part of the global
+// context by the time a handler panics. Emitting at LevelFatal flushes any
+// async sinks synchronously before Write returns (see sinks.go), so no
+// explicit flush is needed here.
+func (l *LambdaLogger) recoverPanic(r any) error {
+	stack := debug.Stack()
+	msg := fmt.Sprintf("%v", r)
+
+	payload := l.buildLogObject(LevelFatal, "panic recovered", nil)
+	payload[KeyError] = msg
+	payload[KeyErrorDetails] = Map{
+		"type":        fmt.Sprintf("%T", r),
+		"message":     msg,
+		"stack":       string(stack),
+		"goroutineId": goroutineID(stack),
+	}
+	_ = l.emit(LevelFatal, payload)
+
+	return fmt.Errorf("panic recovered: %s", msg)
+}
+
+// goroutineID extracts the numeric goroutine ID from the first line of a
+// runtime/debug.Stack() dump ("goroutine 123 [running]:"), returning 0 if it
+// can't be parsed.
+func goroutineID(stack []byte) int64 {
+	line := stack
+	if i := bytes.IndexByte(stack, '\n'); i >= 0 {
+		line = stack[:i]
+	}
+	fields := bytes.Fields(line)
+	if len(fields) < 2 {
+		return 0
+	}
+	id, _ := strconv.ParseInt(string(fields[1]), 10, 64)
+	return id
+}
+
+// WrapHandlerFunc wraps a typed Lambda handler (the func(context.Context,
+// TIn) (TOut, error) signature lambda.Start accepts) so that a panic during
+// invocation is recovered and logged as a single FATAL record instead of
+// crashing the process, with the recovered value returned as an error so
+// Lambda still marks the invocation as failed.
+func WrapHandlerFunc[TIn, TOut any](l *LambdaLogger, handler func(context.Context, TIn) (TOut, error)) func(context.Context, TIn) (TOut, error) {
+	return func(ctx context.Context, event TIn) (out TOut, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = l.recoverPanic(r)
+			}
+		}()
+		return handler(ctx, event)
+	}
+}
+
+// WrapHandler wraps an untyped Lambda handler, for callers that decode the
+// event themselves (e.g. lambda.Start(handler) where the event shape varies
+// per deployment). It recovers panics the same way as WrapHandlerFunc.
+func WrapHandler(l *LambdaLogger, handler func(context.Context, json.RawMessage) (any, error)) func(context.Context, json.RawMessage) (any, error) {
+	return func(ctx context.Context, event json.RawMessage) (out any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = l.recoverPanic(r)
+			}
+		}()
+		return handler(ctx, event)
+	}
+}
@@ -1,7 +1,10 @@
 package logger
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"os"
 	"testing"
 
@@ -61,6 +64,56 @@ func TestAddLambdaContextNoContext(t *testing.T) {
 	}
 }
 
+func TestAddLambdaContextParsesXRayTraceHeader(t *testing.T) {
+	resetGlobalContext()
+	os.Setenv("_X_AMZN_TRACE_ID", "Root=1-5e1b4151-5ac6c58dc39a5b4e2b6a6d2a;Parent=7df1b07be5b0c2b4;Sampled=1")
+	defer os.Unsetenv("_X_AMZN_TRACE_ID")
+
+	l := Default()
+	ll := NewLambdaLogger(l)
+
+	ctx := lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{
+		AwsRequestID: "req-123",
+	})
+	ll.AddLambdaContext(ctx)
+
+	logCtx := ll.Context()
+	if logCtx[KeyTraceID] != "1-5e1b4151-5ac6c58dc39a5b4e2b6a6d2a" {
+		t.Errorf("traceId = %v, want the X-Ray root ID", logCtx[KeyTraceID])
+	}
+
+	xray, ok := logCtx["xray"].(Map)
+	if !ok {
+		t.Fatal("xray context should be present")
+	}
+	if xray["rootId"] != "1-5e1b4151-5ac6c58dc39a5b4e2b6a6d2a" {
+		t.Errorf("xray.rootId = %v, want the root ID", xray["rootId"])
+	}
+	if xray["parentId"] != "7df1b07be5b0c2b4" {
+		t.Errorf("xray.parentId = %v, want %q", xray["parentId"], "7df1b07be5b0c2b4")
+	}
+	if xray["sampled"] != true {
+		t.Errorf("xray.sampled = %v, want true", xray["sampled"])
+	}
+}
+
+func TestAddLambdaContextNoXRayHeaderIsNoOp(t *testing.T) {
+	resetGlobalContext()
+	os.Unsetenv("_X_AMZN_TRACE_ID")
+
+	l := Default()
+	ll := NewLambdaLogger(l)
+
+	ctx := lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{
+		AwsRequestID: "req-123",
+	})
+	ll.AddLambdaContext(ctx)
+
+	if _, ok := ll.Context()["xray"]; ok {
+		t.Error("xray context should not be present without an X-Ray trace header")
+	}
+}
+
 func TestGetLambdaEnvironmentContext(t *testing.T) {
 	os.Setenv("AWS_LAMBDA_FUNCTION_NAME", "test-function")
 	os.Setenv("AWS_REGION", "us-east-1")
@@ -200,6 +253,172 @@ func TestAddAPIGatewayContext(t *testing.T) {
 	}
 }
 
+func TestAddSNSRecordContext(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+	ll := NewLambdaLogger(l)
+
+	record := events.SNSEventRecord{
+		EventSubscriptionArn: "arn:aws:sns:us-east-1:123:my-topic:sub-id",
+		SNS: events.SNSEntity{
+			MessageID: "sns-msg-123",
+			TopicArn:  "arn:aws:sns:us-east-1:123:my-topic",
+			Subject:   "alert",
+		},
+	}
+
+	ll.AddSNSRecordContext(record)
+
+	snsMap, ok := ll.Context()["sns"].(Map)
+	if !ok {
+		t.Fatal("sns context should be present")
+	}
+	if snsMap["messageId"] != "sns-msg-123" {
+		t.Errorf("sns.messageId = %v, want %q", snsMap["messageId"], "sns-msg-123")
+	}
+	if ll.CorrelationID() != "sns-msg-123" {
+		t.Errorf("correlationId = %q, want %q", ll.CorrelationID(), "sns-msg-123")
+	}
+}
+
+func TestAddKinesisRecordContext(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+	ll := NewLambdaLogger(l)
+
+	record := events.KinesisEventRecord{
+		EventID:        "shardId-1:seq-1",
+		EventSourceArn: "arn:aws:kinesis:us-east-1:123:stream/my-stream",
+		Kinesis: events.KinesisRecord{
+			PartitionKey:   "partition-1",
+			SequenceNumber: "seq-1",
+			Data:           []byte("payload"),
+		},
+	}
+
+	ll.AddKinesisRecordContext(record)
+
+	kinesisMap, ok := ll.Context()["kinesis"].(Map)
+	if !ok {
+		t.Fatal("kinesis context should be present")
+	}
+	if kinesisMap["sequenceNumber"] != "seq-1" {
+		t.Errorf("kinesis.sequenceNumber = %v, want %q", kinesisMap["sequenceNumber"], "seq-1")
+	}
+	if ll.CorrelationID() != "seq-1" {
+		t.Errorf("correlationId = %q, want %q", ll.CorrelationID(), "seq-1")
+	}
+}
+
+func TestAddS3RecordContext(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+	ll := NewLambdaLogger(l)
+
+	record := events.S3EventRecord{
+		EventName: "ObjectCreated:Put",
+		S3: events.S3Entity{
+			Bucket: events.S3Bucket{Name: "my-bucket"},
+			Object: events.S3Object{Key: "path/to/file.json", ETag: "etag-123"},
+		},
+		ResponseElements: map[string]string{"x-amz-request-id": "s3-req-1"},
+	}
+
+	ll.AddS3RecordContext(record)
+
+	s3Map, ok := ll.Context()["s3"].(Map)
+	if !ok {
+		t.Fatal("s3 context should be present")
+	}
+	if s3Map["bucket"] != "my-bucket" {
+		t.Errorf("s3.bucket = %v, want %q", s3Map["bucket"], "my-bucket")
+	}
+	if ll.CorrelationID() != "s3-req-1" {
+		t.Errorf("correlationId = %q, want %q", ll.CorrelationID(), "s3-req-1")
+	}
+}
+
+func TestAddHTTPAPIContext(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+	ll := NewLambdaLogger(l)
+
+	request := events.APIGatewayV2HTTPRequest{
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			RequestID: "httpapi-req-1",
+			Stage:     "prod",
+			APIID:     "api-456",
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method: "GET",
+				Path:   "/widgets",
+			},
+		},
+	}
+
+	ll.AddHTTPAPIContext(request)
+
+	httpApiMap, ok := ll.Context()["httpApi"].(Map)
+	if !ok {
+		t.Fatal("httpApi context should be present")
+	}
+	if httpApiMap["requestId"] != "httpapi-req-1" {
+		t.Errorf("httpApi.requestId = %v, want %q", httpApiMap["requestId"], "httpapi-req-1")
+	}
+	if ll.CorrelationID() != "httpapi-req-1" {
+		t.Errorf("correlationId = %q, want %q", ll.CorrelationID(), "httpapi-req-1")
+	}
+}
+
+func TestSlimDownLocallyStripsKinesisDataAndDynamoDBImages(t *testing.T) {
+	resetGlobalContext()
+	os.Setenv("IS_LOCAL", "true")
+	defer os.Unsetenv("IS_LOCAL")
+
+	l := Default()
+	ll := NewLambdaLogger(l)
+
+	ll.AddBaseContext(Map{
+		"kinesis": Map{"sequenceNumber": "seq-1", "data": []byte("payload")},
+		"dynamodb": Map{
+			"eventName": "INSERT",
+			"newImage":  Map{"id": Map{"S": "1"}},
+			"oldImage":  Map{"id": Map{"S": "1"}},
+		},
+		"s3": Map{"object": Map{"key": "file.json", "eTag": "etag-123"}},
+	})
+
+	ll.SlimDownLocally()
+
+	logCtx := ll.Context()
+
+	if kinesis, ok := logCtx["kinesis"].(Map); ok {
+		if _, ok := kinesis["data"]; ok {
+			t.Error("kinesis.data should be removed locally")
+		}
+		if kinesis["sequenceNumber"] != "seq-1" {
+			t.Error("kinesis.sequenceNumber should be kept")
+		}
+	}
+	if dynamodb, ok := logCtx["dynamodb"].(Map); ok {
+		if _, ok := dynamodb["newImage"]; ok {
+			t.Error("dynamodb.newImage should be removed locally")
+		}
+		if _, ok := dynamodb["oldImage"]; ok {
+			t.Error("dynamodb.oldImage should be removed locally")
+		}
+	}
+	if s3, ok := logCtx["s3"].(Map); ok {
+		if object, ok := s3["object"].(Map); ok {
+			if _, ok := object["eTag"]; ok {
+				t.Error("s3.object.eTag should be removed locally")
+			}
+			if object["key"] != "file.json" {
+				t.Error("s3.object.key should be kept")
+			}
+		}
+	}
+}
+
 func TestSlimDownLocally(t *testing.T) {
 	resetGlobalContext()
 	os.Setenv("IS_LOCAL", "true")
@@ -284,3 +503,97 @@ func TestLambdaLoggerChainsWithBaseLogger(t *testing.T) {
 		t.Errorf("context.key = %v, want %q", nested["key"], "value")
 	}
 }
+
+func TestWrapHandlerFuncRecoversPanic(t *testing.T) {
+	resetGlobalContext()
+	var buf bytes.Buffer
+	l := Default()
+	l.SetOutput(&buf)
+	l.prettyPrint = false
+	ll := NewLambdaLogger(l)
+
+	handler := WrapHandlerFunc(ll, func(_ context.Context, _ string) (string, error) {
+		panic("boom")
+	})
+
+	out, err := handler(context.Background(), "event")
+	if err == nil {
+		t.Fatal("expected an error after the recovered panic, got nil")
+	}
+	if out != "" {
+		t.Errorf("out = %q, want zero value", out)
+	}
+
+	var payload map[string]any
+	if decodeErr := json.Unmarshal(buf.Bytes(), &payload); decodeErr != nil {
+		t.Fatalf("failed to parse log output: %v", decodeErr)
+	}
+	if payload[KeyError] != "boom" {
+		t.Errorf("error = %v, want %q", payload[KeyError], "boom")
+	}
+	details, ok := payload[KeyErrorDetails].(map[string]any)
+	if !ok {
+		t.Fatal("errorDetails should be present")
+	}
+	if details["message"] != "boom" {
+		t.Errorf("errorDetails.message = %v, want %q", details["message"], "boom")
+	}
+	if details["stack"] == "" || details["stack"] == nil {
+		t.Error("errorDetails.stack should be populated")
+	}
+}
+
+func TestWrapHandlerFuncPassesThroughSuccess(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+	ll := NewLambdaLogger(l)
+
+	handler := WrapHandlerFunc(ll, func(_ context.Context, in string) (string, error) {
+		return "handled:" + in, nil
+	})
+
+	out, err := handler(context.Background(), "event")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "handled:event" {
+		t.Errorf("out = %q, want %q", out, "handled:event")
+	}
+}
+
+func TestWrapHandlerFuncPassesThroughError(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+	ll := NewLambdaLogger(l)
+	wantErr := errors.New("handler failed")
+
+	handler := WrapHandlerFunc(ll, func(_ context.Context, _ string) (string, error) {
+		return "", wantErr
+	})
+
+	_, err := handler(context.Background(), "event")
+	if !errors.Is(err, wantErr) {
+		t.Errorf("err = %v, want %v", err, wantErr)
+	}
+}
+
+func TestWrapHandlerRecoversPanic(t *testing.T) {
+	resetGlobalContext()
+	var buf bytes.Buffer
+	l := Default()
+	l.SetOutput(&buf)
+	l.prettyPrint = false
+	ll := NewLambdaLogger(l)
+
+	handler := WrapHandler(ll, func(_ context.Context, _ json.RawMessage) (any, error) {
+		panic(errors.New("untyped boom"))
+	})
+
+	_, err := handler(context.Background(), json.RawMessage(`{}`))
+	if err == nil {
+		t.Fatal("expected an error after the recovered panic, got nil")
+	}
+	if buf.Len() == 0 {
+		t.Error("expected a FATAL record to be emitted for the recovered panic")
+	}
+}
@@ -7,11 +7,12 @@
 package logger
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
-	"runtime"
 	"strings"
 	"time"
 
@@ -26,18 +27,66 @@ type Options struct {
 	LogToFile   *bool
 	Rotation    *RotationOptions
 	Context     Map
+	// Sampler, when set, is consulted after level filtering and before a
+	// record is formatted, letting high-volume callers throttle log floods.
+	Sampler Sampler
+	// Sampling, when set and Sampler is nil, builds a BurstSampler from
+	// these options and installs it as the logger's Sampler.
+	Sampling *SamplingOptions
+	// Async, when set, wraps the stdout and file outputs in an AsyncWriter so
+	// Info/Warn/etc. never block on disk or network I/O.
+	Async *AsyncWriterOptions
+	// ContextFilter, when set, is applied to every record (via
+	// ApplyContextConfig) before it reaches the output writer and any
+	// registered Hooks, letting callers drop or redact sensitive fields
+	// process-wide instead of per call site.
+	ContextFilter *ContextConfig
+	// Redactor, when set, is run over every record's merged context (see
+	// FieldRedactor) immediately before serialization, in addition to
+	// whatever ContextFilter applies. Equivalent to calling
+	// Logger.SetRedactor after New.
+	Redactor FieldRedactor
+	// CaptureStacks enables stack capture in errorDetails[].stack for errors
+	// that carry one, either via pkg/errors' StackTrace() method or via
+	// WrapError.
+	CaptureStacks bool
+	// StackSkipPackages elides frames whose function name has one of these
+	// prefixes, in addition to the logger's own frames and Go's runtime and
+	// testing packages.
+	StackSkipPackages []string
+	// Sinks, when set, replaces the logger's default stdout/file sinks
+	// entirely. Use Logger.AddSink to add to the defaults instead.
+	Sinks []Sink
+	// Colorize controls whether prettyJSON output carries ANSI color
+	// escapes on stdout. When nil, it's auto-detected: enabled when
+	// PrettyPrint is true, the stdout sink's writer is a terminal (os.Stdout
+	// in New, or the writer later passed to SetOutput), and NO_COLOR is unset.
+	// File output always carries ANSI regardless of this setting.
+	Colorize *bool
+	// ColorScheme overrides the ANSI colors used for level, name, time,
+	// and message/error highlighting. Defaults to DefaultColorScheme().
+	ColorScheme *ColorScheme
 }
 
 // Logger is a structured JSON logger that writes to stdout and optionally
 // to rotating log files, matching the smooai logging format.
 type Logger struct {
-	name        string
-	level       Level
-	prettyPrint bool
-	logToFile   bool
-	rotation    RotationOptions
-	writer      *rotatingWriter
-	output      io.Writer
+	name              string
+	level             Level
+	prettyPrint       bool
+	logToFile         bool
+	rotation          RotationOptions
+	sinks             []Sink
+	sampler           Sampler
+	extra             Map
+	contextFilter     *ContextConfig
+	redactor          FieldRedactor
+	hookState         *hookState
+	captureStacks     bool
+	stackSkipPackages []string
+	colorize          bool
+	colorizeAuto      bool
+	colorScheme       *ColorScheme
 }
 
 // New creates a new Logger with the given options.
@@ -79,16 +128,16 @@ func New(opts Options) (*Logger, error) {
 		if r.Extension != "" {
 			rotation.Extension = r.Extension
 		}
-		if r.Size != "" {
+		if r.Size != 0 {
 			rotation.Size = r.Size
 		}
-		if r.Interval != "" {
+		if r.Interval != 0 {
 			rotation.Interval = r.Interval
 		}
 		if r.MaxFiles > 0 {
 			rotation.MaxFiles = r.MaxFiles
 		}
-		if r.MaxTotalSize != "" {
+		if r.MaxTotalSize != 0 {
 			rotation.MaxTotalSize = r.MaxTotalSize
 		}
 	}
@@ -101,26 +150,77 @@ func New(opts Options) (*Logger, error) {
 		}
 	}
 
-	var rw *rotatingWriter
-	if logToFile {
-		var err error
-		rw, err = newRotatingWriter(rotation)
-		if err != nil {
-			return nil, fmt.Errorf("init file writer: %w", err)
+	sinks := opts.Sinks
+	if sinks == nil {
+		sinks = []Sink{NewStdoutSink(0, opts.Async)}
+		if logToFile {
+			fileSink, err := NewFileSink(rotation, 0, opts.Async)
+			if err != nil {
+				return nil, fmt.Errorf("init file writer: %w", err)
+			}
+			sinks = append(sinks, fileSink)
 		}
 	}
 
-	return &Logger{
-		name:        name,
-		level:       level,
-		prettyPrint: prettyPrint,
-		logToFile:   logToFile,
-		rotation:    rotation,
-		writer:      rw,
-		output:      os.Stdout,
-	}, nil
+	colorScheme := DefaultColorScheme()
+	if opts.ColorScheme != nil {
+		colorScheme = opts.ColorScheme
+	}
+
+	colorize := prettyPrint && !noColorEnv() && isTerminal(os.Stdout)
+	if opts.Colorize != nil {
+		colorize = *opts.Colorize
+	}
+
+	sampler := opts.Sampler
+	if sampler == nil && opts.Sampling != nil {
+		sampler = NewBurstSampler(*opts.Sampling)
+	}
+
+	l := &Logger{
+		name:              name,
+		level:             level,
+		prettyPrint:       prettyPrint,
+		logToFile:         logToFile,
+		rotation:          rotation,
+		sinks:             sinks,
+		sampler:           sampler,
+		contextFilter:     opts.ContextFilter,
+		redactor:          opts.Redactor,
+		hookState:         &hookState{},
+		captureStacks:     opts.CaptureStacks,
+		stackSkipPackages: opts.StackSkipPackages,
+		colorize:          colorize,
+		colorizeAuto:      opts.Colorize == nil,
+		colorScheme:       colorScheme,
+	}
+
+	return l, nil
 }
 
+// SetOutput redirects the logger's default StdoutSink (if it still has one)
+// to w. Intended for tests and simple embedding scenarios; production code
+// that needs more control should configure Options.Sinks directly. When
+// Options.Colorize was nil, colorize auto-detection is re-run against w.
+// When that sink was created with Options.Async, only Fatal records (which
+// bypass its queue) go to w; queued records still go to the writer its
+// AsyncWriter was created with.
+func (l *Logger) SetOutput(w io.Writer) {
+	for _, s := range l.sinks {
+		if stdout, ok := s.(*StdoutSink); ok {
+			stdout.Writer = w
+			if l.colorizeAuto {
+				l.colorize = l.prettyPrint && !noColorEnv() && isTerminal(w)
+			}
+			return
+		}
+	}
+}
+
+// SetSampler installs a Sampler that is consulted after level filtering and
+// before a record is formatted. Passing nil disables sampling.
+func (l *Logger) SetSampler(s Sampler) { l.sampler = s }
+
 // Default creates a Logger with default settings.
 func Default() *Logger {
 	l, _ := New(Options{})
@@ -183,6 +283,52 @@ func (l *Logger) AddBaseContext(ctx Map) {
 	addBaseContext(ctx)
 }
 
+// WithContext returns a shallow clone of the Logger whose per-record output
+// includes the trace/span IDs found on ctx (via the registered
+// TraceExtractor, see SetTraceExtractor), without mutating the shared
+// global context used by other Logger instances.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	clone := *l
+	clone.extra = nil
+
+	if traceExtractor == nil {
+		return &clone
+	}
+	traceID, spanID, ok := traceExtractor(ctx)
+	if !ok {
+		return &clone
+	}
+
+	extra := make(Map, 2)
+	if traceID != "" {
+		extra[KeyTraceID] = traceID
+	}
+	if spanID != "" {
+		extra[KeySpanID] = spanID
+	}
+	clone.extra = extra
+	return &clone
+}
+
+// WithExtra returns a shallow clone of the Logger whose per-record output
+// merges extra on top of the shared global context, without mutating that
+// global context. It generalizes the cloning WithContext does for trace/span
+// IDs to arbitrary fields, for integrations that need to scope a Logger to a
+// single request or job (e.g. the httpmw subpackage) without racing other
+// concurrent callers through AddBaseContext.
+func (l *Logger) WithExtra(extra Map) *Logger {
+	clone := *l
+	if l.extra == nil {
+		clone.extra = extra
+		return &clone
+	}
+	merged := make(Map, len(l.extra)+len(extra))
+	mergeMaps(merged, l.extra)
+	mergeMaps(merged, extra)
+	clone.extra = merged
+	return &clone
+}
+
 // AddContext merges the given map into the nested "context" field.
 func (l *Logger) AddContext(ctx Map) {
 	contextMu.Lock()
@@ -211,6 +357,12 @@ func (l *Logger) AddHTTPRequest(req HTTPRequest) {
 		} else if corrID, ok := req.Headers["x-correlation-id"]; ok {
 			l.SetCorrelationID(corrID)
 		}
+
+		if tp, ok := req.Headers["traceparent"]; ok {
+			l.applyTraceparent(tp)
+		} else if tp, ok := req.Headers["Traceparent"]; ok {
+			l.applyTraceparent(tp)
+		}
 	}
 	addBaseContext(Map{
 		KeyHTTP: Map{
@@ -233,33 +385,31 @@ func (l *Logger) AddTelemetryFields(fields TelemetryFields) {
 	addBaseContext(structToMap(fields))
 }
 
-// ErrorDetail represents a serialized error for structured logging.
+// ErrorDetail represents a serialized error for structured logging. One
+// ErrorDetail is produced per error in the chain (see flattenErrorChain),
+// outermost first.
 type ErrorDetail struct {
-	Message string `json:"message"`
-	Name    string `json:"name"`
-	Stack   string `json:"stack,omitempty"`
+	Message string       `json:"message"`
+	Name    string       `json:"name"`
+	Stack   []CallerInfo `json:"stack,omitempty"`
 }
 
 // buildLogObject constructs the log payload from the current context and args.
 func (l *Logger) buildLogObject(level Level, msg string, args []any) Map {
 	payload := getGlobalContext()
+	if l.extra != nil {
+		mergeMaps(payload, l.extra)
+	}
 
 	if msg != "" {
 		payload[KeyMessage] = msg
 	}
 
-	var errors []ErrorDetail
+	var errDetails []ErrorDetail
 	for _, arg := range args {
 		switch v := arg.(type) {
 		case error:
-			detail := ErrorDetail{
-				Message: v.Error(),
-				Name:    fmt.Sprintf("%T", v),
-			}
-			buf := make([]byte, 4096)
-			n := runtime.Stack(buf, false)
-			detail.Stack = string(buf[:n])
-			errors = append(errors, detail)
+			errDetails = append(errDetails, l.flattenErrorChain(v)...)
 		case map[string]any:
 			ctx, ok := payload[KeyContext].(Map)
 			if !ok {
@@ -270,15 +420,22 @@ func (l *Logger) buildLogObject(level Level, msg string, args []any) Map {
 		}
 	}
 
-	if len(errors) > 0 {
-		payload[KeyError] = errors[0].Message
-		details := make([]any, len(errors))
-		for i, e := range errors {
-			details[i] = Map{
+	if len(errDetails) > 0 {
+		payload[KeyError] = errDetails[0].Message
+		details := make([]any, len(errDetails))
+		for i, e := range errDetails {
+			detail := Map{
 				"message": e.Message,
 				"name":    e.Name,
-				"stack":   e.Stack,
 			}
+			if len(e.Stack) > 0 {
+				frames := make([]any, len(e.Stack))
+				for j, f := range e.Stack {
+					frames[j] = Map{"file": f.File, "line": f.Line, "function": f.Function}
+				}
+				detail["stack"] = frames
+			}
+			details[i] = detail
 		}
 		payload[KeyErrorDetails] = details
 	}
@@ -299,77 +456,141 @@ func (l *Logger) buildLogObject(level Level, msg string, args []any) Map {
 	return payload
 }
 
-func (l *Logger) emit(payload Map) error {
-	var output string
-	if l.prettyPrint {
-		output = prettyJSON(payload)
-	} else {
-		output = plainJSON(payload)
+// emit dispatches payload to every sink, regardless of whether an earlier
+// sink's Write failed. A flaky network sink (SyslogSink, an HTTP-style
+// AddSink entry, etc.) must not prevent a reliable sink ordered after it
+// (e.g. FileSink) from receiving the record. Any Write errors are joined
+// together and returned to the caller.
+func (l *Logger) emit(level Level, payload Map) error {
+	if l.contextFilter != nil {
+		payload = ApplyContextConfig(payload, l.contextFilter)
 	}
+	if r := l.redactor; r != nil {
+		payload = redactFields(r, nil, payload)
+	} else if fieldRedactor != nil {
+		payload = redactFields(fieldRedactor, nil, payload)
+	}
+	l.fireHooks(level, payload)
 
-	if _, err := io.WriteString(l.output, output); err != nil {
-		return err
+	if metrics != nil {
+		metrics.IncRecords(level, l.name)
 	}
 
-	if l.writer != nil {
-		if err := l.writer.write([]byte(output)); err != nil {
-			return err
+	stdoutRendered, otherRendered := l.render(payload)
+
+	var errs []error
+	for _, s := range l.sinks {
+		rendered := otherRendered
+		if _, ok := s.(*StdoutSink); ok {
+			rendered = stdoutRendered
+		}
+		if err := s.Write(level, payload, rendered); err != nil {
+			errs = append(errs, fmt.Errorf("sink %s: %w", sinkName(s), err))
+			continue
+		}
+		if metrics != nil {
+			metrics.AddBytesWritten(sinkName(s), len(rendered))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// render produces the bytes written to the stdout sink and to every other
+// sink for one record. They differ only when PrettyPrint is on: file (and
+// other) sinks always get ANSI-colored output, while stdout additionally
+// honors l.colorize (itself driven by NO_COLOR and TTY detection unless
+// Options.Colorize was set explicitly).
+func (l *Logger) render(payload Map) (stdoutRendered, otherRendered []byte) {
+	if !l.prettyPrint {
+		plain := []byte(plainJSON(payload))
+		return plain, plain
+	}
+
+	colored := []byte(prettyJSON(payload, l.colorScheme))
+	if l.colorize {
+		return colored, colored
+	}
+	return []byte(prettyJSON(payload, nil)), colored
 }
 
 func (l *Logger) isEnabled(level Level) bool {
 	return level >= l.level
 }
 
+// sampled reports whether a record at level with the given key survives
+// sampling. It is consulted after level filtering and before formatting, so
+// dropped records never reach JSON marshaling and never touch the shared
+// global context.
+func (l *Logger) sampled(level Level, key string) bool {
+	if l.sampler == nil {
+		return true
+	}
+	allowed := l.sampler.Allow(level, key)
+	if !allowed && metrics != nil {
+		metrics.IncDropped("sampled")
+	}
+	return allowed
+}
+
+// withSampledSince attaches a sampled_since field to payload reporting how
+// many records at (level, key) were suppressed since the last one that made
+// it through, for samplers that track that (see BurstSampler).
+func (l *Logger) withSampledSince(level Level, key string, payload Map) Map {
+	if ss, ok := l.sampler.(SuppressedSince); ok {
+		if n := ss.SuppressedSince(level, key); n > 0 {
+			payload[KeySampledSince] = n
+		}
+	}
+	return payload
+}
+
 // Trace logs at TRACE level.
 func (l *Logger) Trace(msg string, args ...any) error {
-	if !l.isEnabled(LevelTrace) {
+	if !l.isEnabled(LevelTrace) || !l.sampled(LevelTrace, msg) {
 		return nil
 	}
-	return l.emit(l.buildLogObject(LevelTrace, msg, args))
+	return l.emit(LevelTrace, l.withSampledSince(LevelTrace, msg, l.buildLogObject(LevelTrace, msg, args)))
 }
 
 // Debug logs at DEBUG level.
 func (l *Logger) Debug(msg string, args ...any) error {
-	if !l.isEnabled(LevelDebug) {
+	if !l.isEnabled(LevelDebug) || !l.sampled(LevelDebug, msg) {
 		return nil
 	}
-	return l.emit(l.buildLogObject(LevelDebug, msg, args))
+	return l.emit(LevelDebug, l.withSampledSince(LevelDebug, msg, l.buildLogObject(LevelDebug, msg, args)))
 }
 
 // Info logs at INFO level.
 func (l *Logger) Info(msg string, args ...any) error {
-	if !l.isEnabled(LevelInfo) {
+	if !l.isEnabled(LevelInfo) || !l.sampled(LevelInfo, msg) {
 		return nil
 	}
-	return l.emit(l.buildLogObject(LevelInfo, msg, args))
+	return l.emit(LevelInfo, l.withSampledSince(LevelInfo, msg, l.buildLogObject(LevelInfo, msg, args)))
 }
 
 // Warn logs at WARN level.
 func (l *Logger) Warn(msg string, args ...any) error {
-	if !l.isEnabled(LevelWarn) {
+	if !l.isEnabled(LevelWarn) || !l.sampled(LevelWarn, msg) {
 		return nil
 	}
-	return l.emit(l.buildLogObject(LevelWarn, msg, args))
+	return l.emit(LevelWarn, l.withSampledSince(LevelWarn, msg, l.buildLogObject(LevelWarn, msg, args)))
 }
 
 // Error logs at ERROR level.
 func (l *Logger) Error(msg string, args ...any) error {
-	if !l.isEnabled(LevelError) {
+	if !l.isEnabled(LevelError) || !l.sampled(LevelError, msg) {
 		return nil
 	}
-	return l.emit(l.buildLogObject(LevelError, msg, args))
+	return l.emit(LevelError, l.withSampledSince(LevelError, msg, l.buildLogObject(LevelError, msg, args)))
 }
 
 // Fatal logs at FATAL level.
 func (l *Logger) Fatal(msg string, args ...any) error {
-	if !l.isEnabled(LevelFatal) {
+	if !l.isEnabled(LevelFatal) || !l.sampled(LevelFatal, msg) {
 		return nil
 	}
-	return l.emit(l.buildLogObject(LevelFatal, msg, args))
+	return l.emit(LevelFatal, l.withSampledSince(LevelFatal, msg, l.buildLogObject(LevelFatal, msg, args)))
 }
 
 // Silent is a no-op log method.
@@ -377,12 +598,35 @@ func (l *Logger) Silent(_ string, _ ...any) error {
 	return nil
 }
 
-// Close flushes and closes the file writer, if any.
+// Close closes every registered sink. It blocks until any queued records and
+// in-flight rotation compression have finished.
 func (l *Logger) Close() error {
-	if l.writer != nil {
-		return l.writer.close()
+	var errs []error
+	for _, s := range l.sinks {
+		if err := s.Close(); err != nil {
+			errs = append(errs, err)
+		}
 	}
-	return nil
+	return errors.Join(errs...)
+}
+
+// asyncDropper is implemented by sinks that wrap an AsyncWriter and can
+// report how many records it has dropped due to backpressure.
+type asyncDropper interface {
+	Dropped() int64
+}
+
+// AsyncDropped returns the total number of records dropped across all async
+// sinks (see Options.Async) due to backpressure, so callers can alert on
+// loss without scraping the optional metrics subsystem.
+func (l *Logger) AsyncDropped() int64 {
+	var total int64
+	for _, s := range l.sinks {
+		if d, ok := s.(asyncDropper); ok {
+			total += d.Dropped()
+		}
+	}
+	return total
 }
 
 // MarshalJSON is a helper that marshals a Map to JSON bytes.
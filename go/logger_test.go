@@ -67,7 +67,7 @@ func TestLoggerLevelFiltering(t *testing.T) {
 	resetGlobalContext()
 	var buf bytes.Buffer
 	l := Default()
-	l.output = &buf
+	l.SetOutput(&buf)
 	l.prettyPrint = false
 
 	_ = l.Debug("should not appear")
@@ -85,7 +85,7 @@ func TestLogOutput(t *testing.T) {
 	resetGlobalContext()
 	var buf bytes.Buffer
 	l := Default()
-	l.output = &buf
+	l.SetOutput(&buf)
 	l.prettyPrint = false
 
 	_ = l.Info("test message", Map{"key": "value"})
@@ -124,7 +124,7 @@ func TestLogWithError(t *testing.T) {
 	resetGlobalContext()
 	var buf bytes.Buffer
 	l := Default()
-	l.output = &buf
+	l.SetOutput(&buf)
 	l.prettyPrint = false
 
 	testErr := fmt.Errorf("something went wrong")
@@ -241,7 +241,7 @@ func TestAllLogLevels(t *testing.T) {
 	resetGlobalContext()
 	var buf bytes.Buffer
 	l := Default()
-	l.output = &buf
+	l.SetOutput(&buf)
 	l.prettyPrint = false
 	l.level = LevelTrace
 
@@ -275,7 +275,7 @@ func TestPrettyOutput(t *testing.T) {
 	resetGlobalContext()
 	var buf bytes.Buffer
 	l := Default()
-	l.output = &buf
+	l.SetOutput(&buf)
 	l.prettyPrint = true
 
 	_ = l.Info("pretty test")
@@ -357,43 +357,74 @@ func TestRemoveNils(t *testing.T) {
 	}
 }
 
-func TestParseSize(t *testing.T) {
+func TestSizeUnmarshalText(t *testing.T) {
 	tests := []struct {
 		input string
-		want  int64
+		want  Size
 	}{
 		{"1K", 1024},
 		{"1M", 1024 * 1024},
 		{"1G", 1024 * 1024 * 1024},
+		{"1KiB", 1024},
+		{"1MiB", 1024 * 1024},
+		{"1.5MiB", Size(1.5 * 1024 * 1024)},
+		{"1KB", 1000},
+		{"1MB", 1_000_000},
 		{"100", 100},
+		{"100B", 100},
 		{"", 0},
 	}
 	for _, tt := range tests {
-		if got := parseSize(tt.input); got != tt.want {
-			t.Errorf("parseSize(%q) = %d, want %d", tt.input, got, tt.want)
+		var got Size
+		if err := got.UnmarshalText([]byte(tt.input)); err != nil {
+			t.Errorf("Size.UnmarshalText(%q) error: %v", tt.input, err)
+			continue
 		}
+		if got != tt.want {
+			t.Errorf("Size.UnmarshalText(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestSizeUnmarshalTextRejectsMalformed(t *testing.T) {
+	var sz Size
+	if err := sz.UnmarshalText([]byte("not-a-size")); err == nil {
+		t.Error("expected an error for malformed size input")
 	}
 }
 
-func TestParseDuration(t *testing.T) {
+func TestDurationUnmarshalText(t *testing.T) {
 	tests := []struct {
 		input string
-		want  time.Duration
+		want  Duration
 	}{
-		{"30s", 30 * time.Second},
-		{"5m", 5 * time.Minute},
-		{"2h", 2 * time.Hour},
-		{"1d", 24 * time.Hour},
-		{"1w", 7 * 24 * time.Hour},
+		{"30s", Duration(30 * time.Second)},
+		{"5m", Duration(5 * time.Minute)},
+		{"2h", Duration(2 * time.Hour)},
+		{"1h30m", Duration(90 * time.Minute)},
+		{"1d", Duration(24 * time.Hour)},
+		{"1w", Duration(7 * 24 * time.Hour)},
 		{"", 0},
 	}
 	for _, tt := range tests {
-		if got := parseDuration(tt.input); got != tt.want {
-			t.Errorf("parseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+		var got Duration
+		if err := got.UnmarshalText([]byte(tt.input)); err != nil {
+			t.Errorf("Duration.UnmarshalText(%q) error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Duration.UnmarshalText(%q) = %v, want %v", tt.input, time.Duration(got), time.Duration(tt.want))
 		}
 	}
 }
 
+func TestDurationUnmarshalTextRejectsMalformed(t *testing.T) {
+	var d Duration
+	if err := d.UnmarshalText([]byte("not-a-duration")); err == nil {
+		t.Error("expected an error for malformed duration input")
+	}
+}
+
 func TestResetContext(t *testing.T) {
 	resetGlobalContext()
 	l := Default()
@@ -416,10 +447,10 @@ func TestFileRotation(t *testing.T) {
 		Path:           dir,
 		FilenamePrefix: "test",
 		Extension:      "log",
-		Size:           "100",
-		Interval:       "1d",
+		Size:           100,
+		Interval:       Duration(24 * time.Hour),
 		MaxFiles:       5,
-		MaxTotalSize:   "1K",
+		MaxTotalSize:   1024,
 	}
 
 	w, err := newRotatingWriter(opts)
@@ -437,6 +468,141 @@ func TestFileRotation(t *testing.T) {
 	}
 }
 
+func TestFileRotationWithCompression(t *testing.T) {
+	dir := t.TempDir()
+	opts := RotationOptions{
+		Path:           dir,
+		FilenamePrefix: "test",
+		Extension:      "log",
+		Size:           50,
+		Interval:       Duration(24 * time.Hour),
+		MaxFiles:       5,
+		Compress:       true,
+	}
+
+	w, err := newRotatingWriter(opts)
+	if err != nil {
+		t.Fatalf("newRotatingWriter failed: %v", err)
+	}
+
+	data := []byte(strings.Repeat("x", 50) + "\n")
+	for i := 0; i < 3; i++ {
+		if err := w.write(data); err != nil {
+			t.Fatalf("write %d failed: %v", i, err)
+		}
+	}
+
+	if err := w.close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(w.currentDir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	var sawGz bool
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".gz") {
+			sawGz = true
+		}
+	}
+	if !sawGz {
+		t.Error("expected at least one rotated file to be gzip-compressed")
+	}
+}
+
+func TestFileRotationCompressAfterDelaysUntilClose(t *testing.T) {
+	dir := t.TempDir()
+	opts := RotationOptions{
+		Path:           dir,
+		FilenamePrefix: "test",
+		Extension:      "log",
+		Size:           50,
+		Interval:       Duration(24 * time.Hour),
+		MaxFiles:       5,
+		Compress:       true,
+		CompressAfter:  time.Hour,
+	}
+
+	w, err := newRotatingWriter(opts)
+	if err != nil {
+		t.Fatalf("newRotatingWriter failed: %v", err)
+	}
+
+	data := []byte(strings.Repeat("x", 50) + "\n")
+	for i := 0; i < 2; i++ {
+		if err := w.write(data); err != nil {
+			t.Fatalf("write %d failed: %v", i, err)
+		}
+	}
+
+	// Give the background goroutine a moment to reach its delay wait; since
+	// CompressAfter is an hour, no .gz file should exist yet.
+	time.Sleep(10 * time.Millisecond)
+	entries, _ := os.ReadDir(w.currentDir)
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".gz") {
+			t.Fatal("file should not be compressed before CompressAfter elapses")
+		}
+	}
+
+	// close() must not block for the full delay; it should compress
+	// immediately instead.
+	if err := w.close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	entries, err = os.ReadDir(w.currentDir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	var sawGz bool
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".gz") {
+			sawGz = true
+		}
+	}
+	if !sawGz {
+		t.Error("expected close() to compress the pending file instead of waiting out CompressAfter")
+	}
+}
+
+func TestEnforceLimitsCountsGzFilesWithOriginals(t *testing.T) {
+	dir := t.TempDir()
+	opts := RotationOptions{
+		Path:           dir,
+		FilenamePrefix: "test",
+		Extension:      "log",
+		Size:           50,
+		Interval:       Duration(24 * time.Hour),
+		MaxFiles:       2,
+		Compress:       true,
+	}
+
+	w, err := newRotatingWriter(opts)
+	if err != nil {
+		t.Fatalf("newRotatingWriter failed: %v", err)
+	}
+
+	data := []byte(strings.Repeat("x", 50) + "\n")
+	for i := 0; i < 5; i++ {
+		if err := w.write(data); err != nil {
+			t.Fatalf("write %d failed: %v", i, err)
+		}
+	}
+	if err := w.close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(w.currentDir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	if len(entries) > opts.MaxFiles {
+		t.Errorf("got %d files, want at most MaxFiles=%d across both .log and .gz", len(entries), opts.MaxFiles)
+	}
+}
+
 func TestLogToFile(t *testing.T) {
 	dir := t.TempDir()
 	resetGlobalContext()
@@ -458,7 +624,7 @@ func TestLogToFile(t *testing.T) {
 	defer l.Close()
 
 	var buf bytes.Buffer
-	l.output = &buf
+	l.SetOutput(&buf)
 
 	_ = l.Info("file test message")
 
@@ -558,7 +724,7 @@ func TestErrorWithoutMessage(t *testing.T) {
 	resetGlobalContext()
 	var buf bytes.Buffer
 	l := Default()
-	l.output = &buf
+	l.SetOutput(&buf)
 	l.prettyPrint = false
 
 	testErr := fmt.Errorf("standalone error")
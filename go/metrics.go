@@ -0,0 +1,43 @@
+package logger
+
+// Metrics receives counters for logger activity, letting services scrape
+// log health the same way they scrape any other internal stat, without this
+// package importing a metrics client directly. Register an implementation
+// via SetMetrics; the metrics subpackage provides one backed by
+// prometheus.Collector.
+type Metrics interface {
+	// IncRecords increments the emitted-record counter for level and the
+	// logger's name.
+	IncRecords(level Level, name string)
+	// AddBytesWritten adds n to the bytes-written counter for sink (e.g.
+	// "stdout", "file", "syslog", "journald", or "custom" for a
+	// caller-supplied Sink).
+	AddBytesWritten(sink string, n int)
+	// IncRotations increments the log file rotation counter.
+	IncRotations()
+	// IncDropped increments the dropped-record counter for reason (e.g.
+	// "sampled").
+	IncDropped(reason string)
+}
+
+var metrics Metrics
+
+// SetMetrics installs the Metrics implementation used to report logger
+// activity. Passing nil disables metrics reporting.
+func SetMetrics(m Metrics) { metrics = m }
+
+// sinkName returns the metrics label for a Sink's bytes-written counter.
+func sinkName(s Sink) string {
+	switch s.(type) {
+	case *StdoutSink:
+		return "stdout"
+	case *FileSink:
+		return "file"
+	case *SyslogSink:
+		return "syslog"
+	case *JournaldSink:
+		return "journald"
+	default:
+		return "custom"
+	}
+}
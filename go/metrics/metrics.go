@@ -0,0 +1,66 @@
+// Package metrics wires Prometheus counters into
+// github.com/SmooAI/logger/go without requiring the core logger package to
+// depend on the Prometheus client.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	logger "github.com/SmooAI/logger/go"
+)
+
+// Collector implements logger.Metrics with four prometheus.Collectors:
+// records emitted (by level and logger name), bytes written (by sink),
+// rotations, and dropped records (by reason).
+type Collector struct {
+	records  *prometheus.CounterVec
+	bytes    *prometheus.CounterVec
+	rotation prometheus.Counter
+	dropped  *prometheus.CounterVec
+}
+
+// New creates a Collector and registers its counters with reg. Pass
+// prometheus.DefaultRegisterer to use the global registry.
+func New(reg prometheus.Registerer) *Collector {
+	c := &Collector{
+		records: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "smooai_log_records_total",
+			Help: "Total number of log records emitted, by level and logger name.",
+		}, []string{"level", "name"}),
+		bytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "smooai_log_bytes_written_total",
+			Help: "Total bytes written to log sinks, by sink.",
+		}, []string{"sink"}),
+		rotation: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "smooai_log_rotations_total",
+			Help: "Total number of log file rotations.",
+		}),
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "smooai_log_dropped_total",
+			Help: "Total number of log records dropped before reaching a sink, by reason.",
+		}, []string{"reason"}),
+	}
+
+	reg.MustRegister(c.records, c.bytes, c.rotation, c.dropped)
+	return c
+}
+
+// IncRecords implements logger.Metrics.
+func (c *Collector) IncRecords(level logger.Level, name string) {
+	c.records.WithLabelValues(level.String(), name).Inc()
+}
+
+// AddBytesWritten implements logger.Metrics.
+func (c *Collector) AddBytesWritten(sink string, n int) {
+	c.bytes.WithLabelValues(sink).Add(float64(n))
+}
+
+// IncRotations implements logger.Metrics.
+func (c *Collector) IncRotations() {
+	c.rotation.Inc()
+}
+
+// IncDropped implements logger.Metrics.
+func (c *Collector) IncDropped(reason string) {
+	c.dropped.WithLabelValues(reason).Inc()
+}
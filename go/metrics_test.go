@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+type fakeMetrics struct {
+	records  []string // level + ":" + name
+	bytes    map[string]int
+	rotation int
+	dropped  map[string]int
+}
+
+func newFakeMetrics() *fakeMetrics {
+	return &fakeMetrics{bytes: map[string]int{}, dropped: map[string]int{}}
+}
+
+func (m *fakeMetrics) IncRecords(level Level, name string) {
+	m.records = append(m.records, level.String()+":"+name)
+}
+func (m *fakeMetrics) AddBytesWritten(sink string, n int) { m.bytes[sink] += n }
+func (m *fakeMetrics) IncRotations()                      { m.rotation++ }
+func (m *fakeMetrics) IncDropped(reason string)           { m.dropped[reason]++ }
+
+func TestSetMetricsCountsRecordsAndBytes(t *testing.T) {
+	resetGlobalContext()
+	fm := newFakeMetrics()
+	SetMetrics(fm)
+	defer SetMetrics(nil)
+
+	var buf bytes.Buffer
+	l := Default()
+	l.SetOutput(&buf)
+	l.SetName("svc")
+
+	_ = l.Info("hello")
+
+	if len(fm.records) != 1 || fm.records[0] != "info:svc" {
+		t.Errorf("records = %v, want one \"info:svc\" entry", fm.records)
+	}
+	if fm.bytes["stdout"] == 0 {
+		t.Error("expected bytes written to the stdout sink to be counted")
+	}
+}
+
+func TestSetMetricsCountsSampledDrops(t *testing.T) {
+	resetGlobalContext()
+	fm := newFakeMetrics()
+	SetMetrics(fm)
+	defer SetMetrics(nil)
+
+	l := Default()
+	l.SetOutput(&bytes.Buffer{})
+	l.SetSampler(&TokenBucketSampler{Rate: 0, Burst: 0})
+
+	_ = l.Info("dropped")
+
+	if fm.dropped["sampled"] != 1 {
+		t.Errorf("dropped[\"sampled\"] = %d, want 1", fm.dropped["sampled"])
+	}
+	if len(fm.records) != 0 {
+		t.Error("a sampled-out record should not also count as emitted")
+	}
+}
+
+func TestSetMetricsNilDisablesReporting(t *testing.T) {
+	resetGlobalContext()
+	SetMetrics(nil)
+
+	l := Default()
+	l.SetOutput(&bytes.Buffer{})
+
+	if err := l.Info("hello"); err != nil {
+		t.Fatalf("Info() with metrics disabled: %v", err)
+	}
+}
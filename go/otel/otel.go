@@ -0,0 +1,27 @@
+// Package otel wires OpenTelemetry trace/span correlation into
+// github.com/SmooAI/logger/go without requiring the core logger package to
+// depend on the OpenTelemetry SDK.
+package otel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+
+	logger "github.com/SmooAI/logger/go"
+)
+
+func init() {
+	logger.SetTraceExtractor(Extract)
+}
+
+// Extract pulls the trace ID and span ID off the active OpenTelemetry span
+// in ctx, if any, using the standard OTLP hex encodings (32-hex trace ID,
+// 16-hex span ID).
+func Extract(ctx context.Context) (traceID, spanID string, ok bool) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return "", "", false
+	}
+	return sc.TraceID().String(), sc.SpanID().String(), true
+}
@@ -0,0 +1,38 @@
+package otel
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestExtractValidSpanContext(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	gotTraceID, gotSpanID, ok := Extract(ctx)
+	if !ok {
+		t.Fatal("Extract should report ok for a valid span context")
+	}
+	if gotTraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("traceID = %q, want %q", gotTraceID, "4bf92f3577b34da6a3ce929d0e0e4736")
+	}
+	if gotSpanID != "00f067aa0ba902b7" {
+		t.Errorf("spanID = %q, want %q", gotSpanID, "00f067aa0ba902b7")
+	}
+}
+
+func TestExtractNoSpanContext(t *testing.T) {
+	_, _, ok := Extract(context.Background())
+	if ok {
+		t.Error("Extract should report !ok when no span context is present")
+	}
+}
@@ -17,24 +17,116 @@ const (
 	ansiRed   = "\033[38;2;231;76;60m"
 )
 
-// prettyJSON formats a log payload as pretty-printed JSON with ANSI color
-// highlights for msg, time, and error fields, followed by separator lines.
-func prettyJSON(payload Map) string {
+// ColorAttribute is a single ANSI display attribute: a foreground color, an
+// optional background color, and a bold flag. A zero-value ColorAttribute
+// renders as no escape sequence at all.
+type ColorAttribute struct {
+	FG   string
+	BG   string
+	Bold bool
+}
+
+// Sequence returns the combined ANSI escape sequence for a, or "" if a has
+// no foreground, background, or bold set.
+func (a ColorAttribute) Sequence() string {
+	if a.FG == "" && a.BG == "" && !a.Bold {
+		return ""
+	}
+	var sb strings.Builder
+	if a.Bold {
+		sb.WriteString(ansiBold)
+	}
+	sb.WriteString(a.FG)
+	sb.WriteString(a.BG)
+	return sb.String()
+}
+
+// ColorScheme assigns a ColorAttribute to each log level plus the name,
+// time, message, and error tokens highlighted by prettyJSON.
+type ColorScheme struct {
+	Trace ColorAttribute
+	Debug ColorAttribute
+	Info  ColorAttribute
+	Warn  ColorAttribute
+	Error ColorAttribute
+	Fatal ColorAttribute
+
+	Name       ColorAttribute
+	Time       ColorAttribute
+	Message    ColorAttribute
+	ErrorField ColorAttribute
+}
+
+// DefaultColorScheme returns the scheme prettyJSON uses when a Logger has
+// colorization enabled but no custom ColorScheme was supplied. It keeps the
+// green/blue/red highlights prettyJSON has always used for msg/time/error
+// and adds a severity ramp for the level token.
+func DefaultColorScheme() *ColorScheme {
+	return &ColorScheme{
+		Trace: ColorAttribute{FG: "\033[38;2;149;165;166m"},
+		Debug: ColorAttribute{FG: ansiBlue},
+		Info:  ColorAttribute{FG: ansiGreen, Bold: true},
+		Warn:  ColorAttribute{FG: "\033[38;2;241;196;15m", Bold: true},
+		Error: ColorAttribute{FG: ansiRed, Bold: true},
+		Fatal: ColorAttribute{FG: "\033[38;2;255;255;255m", BG: "\033[48;2;192;57;43m", Bold: true},
+
+		Name:       ColorAttribute{FG: ansiBlue},
+		Time:       ColorAttribute{FG: ansiBlue},
+		Message:    ColorAttribute{FG: ansiGreen, Bold: true},
+		ErrorField: ColorAttribute{FG: ansiRed},
+	}
+}
+
+// forLevel returns the ColorAttribute s assigns to level.
+func (s *ColorScheme) forLevel(level Level) ColorAttribute {
+	switch level {
+	case LevelTrace:
+		return s.Trace
+	case LevelDebug:
+		return s.Debug
+	case LevelWarn:
+		return s.Warn
+	case LevelError:
+		return s.Error
+	case LevelFatal:
+		return s.Fatal
+	default:
+		return s.Info
+	}
+}
+
+// prettyJSON formats a log payload as pretty-printed JSON with highlights
+// for level, name, msg, time, and error fields, followed by separator
+// lines. scheme controls the ANSI colors used for those highlights; a nil
+// scheme renders the same structure with no color escape sequences, for
+// callers that want pretty formatting without ANSI (e.g. a non-TTY stdout).
+func prettyJSON(payload Map, scheme *ColorScheme) string {
 	data, err := json.MarshalIndent(payload, "", "  ")
 	if err != nil {
 		return "{}\n"
 	}
 
+	level, _ := payload[KeyLevel].(int)
+
 	var sb strings.Builder
 	for _, line := range strings.Split(string(data), "\n") {
 		trimmed := strings.TrimSpace(line)
 		switch {
 		case strings.HasPrefix(trimmed, `"msg"`):
-			sb.WriteString(highlightKey(line, ansiGreen+ansiBold, ansiReset))
+			sb.WriteString(highlightKey(line, colorSeq(scheme, func(s *ColorScheme) ColorAttribute { return s.Message })))
 		case strings.HasPrefix(trimmed, `"time"`):
-			sb.WriteString(highlightKey(line, ansiBlue, ansiReset))
+			sb.WriteString(highlightKey(line, colorSeq(scheme, func(s *ColorScheme) ColorAttribute { return s.Time })))
+		case strings.HasPrefix(trimmed, `"name"`):
+			sb.WriteString(highlightKey(line, colorSeq(scheme, func(s *ColorScheme) ColorAttribute { return s.Name })))
+		case strings.HasPrefix(trimmed, `"level"`) || strings.HasPrefix(trimmed, `"LogLevel"`):
+			sb.WriteString(highlightKey(line, colorSeq(scheme, func(s *ColorScheme) ColorAttribute { return s.forLevel(Level(level)) })))
 		case strings.HasPrefix(trimmed, `"error"`) || strings.HasPrefix(trimmed, `"errorDetails"`):
-			sb.WriteString(fmt.Sprintf("%s%s%s", ansiRed, line, ansiReset))
+			seq := colorSeq(scheme, func(s *ColorScheme) ColorAttribute { return s.ErrorField })
+			if seq == "" {
+				sb.WriteString(line)
+			} else {
+				sb.WriteString(fmt.Sprintf("%s%s%s", seq, line, ansiReset))
+			}
 		default:
 			sb.WriteString(line)
 		}
@@ -51,6 +143,15 @@ func prettyJSON(payload Map) string {
 	return sb.String()
 }
 
+// colorSeq returns the ANSI sequence pick(scheme) resolves to, or "" if
+// scheme is nil (colorization disabled).
+func colorSeq(scheme *ColorScheme, pick func(*ColorScheme) ColorAttribute) string {
+	if scheme == nil {
+		return ""
+	}
+	return pick(scheme).Sequence()
+}
+
 // plainJSON formats a log payload as compact single-line JSON.
 func plainJSON(payload Map) string {
 	data, err := json.Marshal(payload)
@@ -60,10 +161,13 @@ func plainJSON(payload Map) string {
 	return string(data) + "\n"
 }
 
-func highlightKey(line, colorStart, colorEnd string) string {
+func highlightKey(line, colorSeq string) string {
+	if colorSeq == "" {
+		return line
+	}
 	idx := strings.Index(line, ":")
 	if idx < 0 {
 		return line
 	}
-	return fmt.Sprintf("%s%s%s%s", colorStart, line[:idx], colorEnd, line[idx:])
+	return fmt.Sprintf("%s%s%s%s", colorSeq, line[:idx], ansiReset, line[idx:])
 }
@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrettyJSONWithNilSchemeOmitsANSI(t *testing.T) {
+	payload := Map{KeyLevel: int(LevelError), KeyLogLevel: "error", KeyMessage: "boom"}
+
+	out := prettyJSON(payload, nil)
+	if strings.Contains(out, "\033[") {
+		t.Errorf("expected no ANSI escapes with a nil scheme, got %q", out)
+	}
+	if !strings.Contains(out, `"msg": "boom"`) {
+		t.Errorf("expected formatting to be preserved, got %q", out)
+	}
+}
+
+func TestPrettyJSONWithSchemeColorsLevelToken(t *testing.T) {
+	payload := Map{KeyLevel: int(LevelError), KeyLogLevel: "error", KeyMessage: "boom"}
+
+	out := prettyJSON(payload, DefaultColorScheme())
+	if !strings.Contains(out, ansiRed) {
+		t.Errorf("expected the error level color in output, got %q", out)
+	}
+}
+
+func TestColorAttributeSequenceEmptyWhenUnset(t *testing.T) {
+	var a ColorAttribute
+	if a.Sequence() != "" {
+		t.Errorf("zero-value ColorAttribute should produce no escape sequence, got %q", a.Sequence())
+	}
+}
+
+func TestLoggerRenderSeparatesStdoutFromOtherSinks(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+	l.prettyPrint = true
+	l.colorize = false
+	l.colorScheme = DefaultColorScheme()
+
+	payload := l.buildLogObject(LevelInfo, "hello", nil)
+	stdoutRendered, otherRendered := l.render(payload)
+
+	if strings.Contains(string(stdoutRendered), "\033[") {
+		t.Error("stdout rendering should be plain when colorize is false")
+	}
+	if !strings.Contains(string(otherRendered), "\033[") {
+		t.Error("non-stdout sinks should still receive ANSI-colored pretty output")
+	}
+}
+
+func TestSetOutputNonTerminalDisablesColorizeByDefault(t *testing.T) {
+	resetGlobalContext()
+	var buf bytes.Buffer
+	prettyPrint := true
+	l, err := New(Options{PrettyPrint: &prettyPrint})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	l.SetOutput(&buf)
+
+	_ = l.Info("hello")
+
+	if strings.Contains(buf.String(), "\033[") {
+		t.Error("a non-terminal stdout sink should not receive ANSI colors")
+	}
+}
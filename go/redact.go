@@ -0,0 +1,79 @@
+package logger
+
+import "regexp"
+
+// Redactor masks matches inside a string value, returning the result. It is
+// used by ContextConfig's ConfigRedact node (see Redact) to scrub secrets
+// and PII before a record reaches the output writer.
+type Redactor func(value string) string
+
+const defaultRedactMask = "[REDACTED]"
+
+var (
+	emailPattern        = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+	jwtPattern          = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+	creditCardPattern   = regexp.MustCompile(`\b(?:\d[ -]?){13,16}\b`)
+	bearerPattern       = regexp.MustCompile(`(?i)Bearer\s+[A-Za-z0-9\-._~+/]+=*`)
+	awsAccessKeyPattern = regexp.MustCompile(`AKIA[0-9A-Z]{16}`)
+)
+
+func maskOrDefault(mask string) string {
+	if mask == "" {
+		return defaultRedactMask
+	}
+	return mask
+}
+
+// RedactEmails masks email addresses, replacing matches with mask ("" uses
+// the default "[REDACTED]").
+func RedactEmails(mask string) Redactor {
+	mask = maskOrDefault(mask)
+	return func(value string) string { return emailPattern.ReplaceAllString(value, mask) }
+}
+
+// RedactJWTs masks JWT-shaped strings (header.payload.signature).
+func RedactJWTs(mask string) Redactor {
+	mask = maskOrDefault(mask)
+	return func(value string) string { return jwtPattern.ReplaceAllString(value, mask) }
+}
+
+// RedactCreditCards masks credit-card-like digit sequences (13-16 digits,
+// optionally grouped with spaces or dashes).
+func RedactCreditCards(mask string) Redactor {
+	mask = maskOrDefault(mask)
+	return func(value string) string { return creditCardPattern.ReplaceAllString(value, mask) }
+}
+
+// RedactBearerTokens masks "Bearer <token>" values, e.g. from an
+// Authorization header, keeping the "Bearer " prefix intact.
+func RedactBearerTokens(mask string) Redactor {
+	mask = maskOrDefault(mask)
+	return func(value string) string { return bearerPattern.ReplaceAllString(value, "Bearer "+mask) }
+}
+
+// RedactAWSAccessKeys masks AWS access key IDs (AKIA followed by 16
+// alphanumeric characters).
+func RedactAWSAccessKeys(mask string) Redactor {
+	mask = maskOrDefault(mask)
+	return func(value string) string { return awsAccessKeyPattern.ReplaceAllString(value, mask) }
+}
+
+// ChainRedactors composes multiple redactors, applying each in order.
+func ChainRedactors(redactors ...Redactor) Redactor {
+	return func(value string) string {
+		for _, r := range redactors {
+			value = r(value)
+		}
+		return value
+	}
+}
+
+// DefaultSecretsRedactor masks bearer tokens, JWTs, AWS access keys, credit
+// cards, and email addresses using the default "[REDACTED]" mask.
+var DefaultSecretsRedactor = ChainRedactors(
+	RedactBearerTokens(""),
+	RedactJWTs(""),
+	RedactAWSAccessKeys(""),
+	RedactCreditCards(""),
+	RedactEmails(""),
+)
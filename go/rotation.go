@@ -1,7 +1,9 @@
 package logger
 
 import (
+	"compress/gzip"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -13,13 +15,16 @@ import (
 
 // RotationOptions configures log file rotation.
 type RotationOptions struct {
-	Path           string // Directory for log files (default: ".smooai-logs")
-	FilenamePrefix string // Prefix for log filenames (default: "output")
-	Extension      string // File extension (default: "ansi")
-	Size           string // Max file size before rotation (e.g., "1M", "10K")
-	Interval       string // Rotation interval (e.g., "1d", "2h")
-	MaxFiles       int    // Max rotated files to keep (default: 30)
-	MaxTotalSize   string // Max total size of all log files (e.g., "100M")
+	Path           string        // Directory for log files (default: ".smooai-logs")
+	FilenamePrefix string        // Prefix for log filenames (default: "output")
+	Extension      string        // File extension (default: "ansi")
+	Size           Size          // Max file size before rotation (e.g., "1MiB", "10KB")
+	Interval       Duration      // Rotation interval (e.g., "1d", "2h")
+	MaxFiles       int           // Max rotated files to keep (default: 30)
+	MaxTotalSize   Size          // Max total size of all log files (e.g., "100MB")
+	Compress       bool          // Gzip rotated-out files in the background
+	CompressLevel  int           // gzip.DefaultCompression is used when zero
+	CompressAfter  time.Duration // Delay before compressing, so recent files stay readable for tailing
 }
 
 // DefaultRotationOptions returns the default rotation configuration.
@@ -28,10 +33,10 @@ func DefaultRotationOptions() RotationOptions {
 		Path:           ".smooai-logs",
 		FilenamePrefix: "output",
 		Extension:      "ansi",
-		Size:           "1M",
-		Interval:       "1d",
+		Size:           1 << 20,
+		Interval:       Duration(24 * time.Hour),
 		MaxFiles:       30,
-		MaxTotalSize:   "100M",
+		MaxTotalSize:   100 << 20,
 	}
 }
 
@@ -47,12 +52,18 @@ type rotatingWriter struct {
 	currentPath    string
 	index          int
 	intervalAnchor time.Time
+	compress       bool
+	compressLevel  int
+	compressAfter  time.Duration
+	compressWG     sync.WaitGroup
+	closeOnce      sync.Once
+	stopC          chan struct{}
 }
 
 func newRotatingWriter(opts RotationOptions) (*rotatingWriter, error) {
-	maxBytes := parseSize(opts.Size)
-	maxTotalBytes := parseSize(opts.MaxTotalSize)
-	interval := parseDuration(opts.Interval)
+	maxBytes := int64(opts.Size)
+	maxTotalBytes := int64(opts.MaxTotalSize)
+	interval := time.Duration(opts.Interval)
 
 	now := time.Now().UTC()
 	dir := logDirectory(opts, now)
@@ -83,9 +94,22 @@ func newRotatingWriter(opts RotationOptions) (*rotatingWriter, error) {
 		currentPath:    path,
 		index:          0,
 		intervalAnchor: now,
+		compress:       opts.Compress,
+		compressLevel:  opts.CompressLevel,
+		compressAfter:  opts.CompressAfter,
+		stopC:          make(chan struct{}),
 	}, nil
 }
 
+// Write implements io.Writer so a rotatingWriter can be wrapped by an
+// AsyncWriter.
+func (w *rotatingWriter) Write(data []byte) (int, error) {
+	if err := w.write(data); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
 func (w *rotatingWriter) write(data []byte) error {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -116,6 +140,7 @@ func (w *rotatingWriter) shouldRotate(now time.Time, additional int64) bool {
 }
 
 func (w *rotatingWriter) rotate(now time.Time) error {
+	closedPath := w.currentPath
 	if w.file != nil {
 		_ = w.file.Close()
 	}
@@ -143,10 +168,80 @@ func (w *rotatingWriter) rotate(now time.Time) error {
 	w.index = nextIndex
 	w.intervalAnchor = now
 
+	if w.compress && closedPath != "" {
+		w.scheduleCompress(closedPath)
+	}
+
 	w.enforceLimits()
+
+	if metrics != nil {
+		metrics.IncRotations()
+	}
 	return nil
 }
 
+// scheduleCompress runs compressFile on a background goroutine, tracked by
+// compressWG so close() can wait for it. If compressAfter is set, the file
+// is left alone (readable for tailing) until that delay elapses or close()
+// is called, whichever comes first. enforceLimits is re-run once the
+// compression lands, since the synchronous call made from rotate() right
+// after scheduling can run before this goroutine has replaced the original
+// file with its .gz — without this follow-up scan, that file would never be
+// counted against MaxFiles/MaxTotalSize.
+func (w *rotatingWriter) scheduleCompress(path string) {
+	w.compressWG.Add(1)
+	go func() {
+		defer w.compressWG.Done()
+		if w.compressAfter > 0 {
+			select {
+			case <-time.After(w.compressAfter):
+			case <-w.stopC:
+			}
+		}
+		w.compressFile(path)
+
+		w.mu.Lock()
+		w.enforceLimits()
+		w.mu.Unlock()
+	}()
+}
+
+// compressFile gzips the rotated-out file at path and removes the original,
+// running on a background goroutine so rotation never blocks the writer.
+func (w *rotatingWriter) compressFile(path string) {
+	src, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return
+	}
+	defer dst.Close()
+
+	level := w.compressLevel
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+	gw, err := gzip.NewWriterLevel(dst, level)
+	if err != nil {
+		return
+	}
+
+	if _, err := io.Copy(gw, src); err != nil {
+		_ = gw.Close()
+		return
+	}
+	if err := gw.Close(); err != nil {
+		return
+	}
+
+	_ = src.Close()
+	_ = os.Remove(path)
+}
+
 func (w *rotatingWriter) enforceLimits() {
 	entries, err := os.ReadDir(w.currentDir)
 	if err != nil {
@@ -165,7 +260,10 @@ func (w *rotatingWriter) enforceLimits() {
 			continue
 		}
 		name := entry.Name()
-		if !strings.HasPrefix(name, w.opts.FilenamePrefix) || !strings.HasSuffix(name, w.opts.Extension) {
+		if !strings.HasPrefix(name, w.opts.FilenamePrefix) {
+			continue
+		}
+		if !strings.HasSuffix(name, w.opts.Extension) && !strings.HasSuffix(name, w.opts.Extension+".gz") {
 			continue
 		}
 		info, err := entry.Info()
@@ -204,6 +302,9 @@ func (w *rotatingWriter) enforceLimits() {
 }
 
 func (w *rotatingWriter) close() error {
+	w.closeOnce.Do(func() { close(w.stopC) })
+	w.compressWG.Wait()
+
 	w.mu.Lock()
 	defer w.mu.Unlock()
 	if w.file != nil {
@@ -226,51 +327,111 @@ func logFilename(opts RotationOptions, t time.Time, index int) string {
 	)
 }
 
-func parseSize(s string) int64 {
-	s = strings.TrimSpace(strings.ToUpper(s))
+// Size is a byte count that implements encoding.TextUnmarshaler/MarshalText
+// so it can be configured from JSON, YAML, or env vars as e.g. "1.5MiB" or
+// "100MB", rather than as a bare integer. Unlike the bare string fields this
+// replaced, malformed input is a parse error instead of a silently-ignored
+// zero.
+type Size int64
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts IEC
+// (KiB/MiB/GiB, base 1024), SI (KB/MB/GB, base 1000), and bare K/M/G
+// (base 1024, for backward compatibility) suffixes, all case-insensitive,
+// with fractional values (e.g. "1.5MiB"). An empty string means zero.
+func (sz *Size) UnmarshalText(text []byte) error {
+	n, err := parseSize(string(text))
+	if err != nil {
+		return err
+	}
+	*sz = Size(n)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, rendering the size as a
+// plain byte count.
+func (sz Size) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(sz), 10)), nil
+}
+
+func parseSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
 	if s == "" {
-		return 0
+		return 0, nil
 	}
-	if strings.HasSuffix(s, "K") {
-		n, _ := strconv.ParseInt(s[:len(s)-1], 10, 64)
-		return n * 1024
+
+	suffixes := []struct {
+		suffix string
+		mult   float64
+	}{
+		{"GIB", 1 << 30}, {"MIB", 1 << 20}, {"KIB", 1 << 10},
+		{"GB", 1e9}, {"MB", 1e6}, {"KB", 1e3},
+		{"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10},
+		{"B", 1},
 	}
-	if strings.HasSuffix(s, "M") {
-		n, _ := strconv.ParseInt(s[:len(s)-1], 10, 64)
-		return n * 1024 * 1024
+
+	upper := strings.ToUpper(s)
+	for _, sfx := range suffixes {
+		if !strings.HasSuffix(upper, sfx.suffix) {
+			continue
+		}
+		numPart := strings.TrimSpace(s[:len(s)-len(sfx.suffix)])
+		n, err := strconv.ParseFloat(numPart, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid size %q: %w", s, err)
+		}
+		return int64(n * sfx.mult), nil
 	}
-	if strings.HasSuffix(s, "G") {
-		n, _ := strconv.ParseInt(s[:len(s)-1], 10, 64)
-		return n * 1024 * 1024 * 1024
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", s, err)
 	}
-	n, _ := strconv.ParseInt(s, 10, 64)
-	return n
+	return int64(n), nil
 }
 
-func parseDuration(s string) time.Duration {
-	s = strings.TrimSpace(strings.ToLower(s))
-	if s == "" {
-		return 0
-	}
-	if strings.HasSuffix(s, "s") {
-		n, _ := strconv.Atoi(s[:len(s)-1])
-		return time.Duration(n) * time.Second
-	}
-	if strings.HasSuffix(s, "m") {
-		n, _ := strconv.Atoi(s[:len(s)-1])
-		return time.Duration(n) * time.Minute
+// Duration is a time.Duration that implements encoding.TextUnmarshaler/
+// MarshalText, delegating to time.ParseDuration and adding the "d" (day)
+// and "w" (week) units the stdlib doesn't support.
+type Duration time.Duration
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts anything
+// time.ParseDuration does ("1h30m", "90s") plus a bare "Nd" or "Nw" suffix
+// for days/weeks. An empty string means zero.
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := parseDuration(string(text))
+	if err != nil {
+		return err
 	}
-	if strings.HasSuffix(s, "h") {
-		n, _ := strconv.Atoi(s[:len(s)-1])
-		return time.Duration(n) * time.Hour
+	*d = Duration(v)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
 	}
-	if strings.HasSuffix(s, "d") {
-		n, _ := strconv.Atoi(s[:len(s)-1])
-		return time.Duration(n) * 24 * time.Hour
+
+	lower := strings.ToLower(s)
+	if unit := lower[len(lower)-1:]; (unit == "d" || unit == "w") && !strings.ContainsAny(lower, "hms") {
+		n, err := strconv.ParseFloat(lower[:len(lower)-1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		if unit == "d" {
+			return time.Duration(n * float64(24*time.Hour)), nil
+		}
+		return time.Duration(n * float64(7*24*time.Hour)), nil
 	}
-	if strings.HasSuffix(s, "w") {
-		n, _ := strconv.Atoi(s[:len(s)-1])
-		return time.Duration(n) * 7 * 24 * time.Hour
+
+	d, err := time.ParseDuration(lower)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", s, err)
 	}
-	return 0
+	return d, nil
 }
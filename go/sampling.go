@@ -0,0 +1,381 @@
+package logger
+
+import (
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Sampler decides whether a log record at the given level should be emitted.
+// Implementations must be safe for concurrent use. key is an optional
+// caller-supplied value (e.g. a request path or error message fingerprint)
+// that key-aware samplers use to make per-key decisions; samplers that don't
+// care about keys can ignore it.
+type Sampler interface {
+	Allow(level Level, key string) bool
+}
+
+// DroppedCounter is implemented by samplers that track how many records they
+// have suppressed, so callers can surface the loss (see Logger.FlushSampling).
+type DroppedCounter interface {
+	Dropped() map[Level]int64
+}
+
+// RateSampler emits 1 of every N records per level.
+type RateSampler struct {
+	N int
+
+	mu     sync.Mutex
+	counts map[Level]int
+}
+
+// Allow implements Sampler.
+func (s *RateSampler) Allow(level Level, _ string) bool {
+	if s.N <= 1 {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.counts == nil {
+		s.counts = make(map[Level]int)
+	}
+	s.counts[level]++
+	return s.counts[level]%s.N == 1
+}
+
+// TokenBucketSampler caps events/sec per level using a token bucket, dropping
+// records once the bucket is empty and tracking how many were dropped.
+type TokenBucketSampler struct {
+	Rate  float64 // tokens added per second
+	Burst float64 // bucket capacity
+
+	mu      sync.Mutex
+	buckets map[Level]*tokenBucketState
+	dropped map[Level]int64
+}
+
+type tokenBucketState struct {
+	tokens   float64
+	lastFill time.Time
+}
+
+// Allow implements Sampler.
+func (s *TokenBucketSampler) Allow(level Level, _ string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.buckets == nil {
+		s.buckets = make(map[Level]*tokenBucketState)
+		s.dropped = make(map[Level]int64)
+	}
+
+	now := time.Now()
+	b, ok := s.buckets[level]
+	if !ok {
+		b = &tokenBucketState{tokens: s.Burst, lastFill: now}
+		s.buckets[level] = b
+	} else {
+		elapsed := now.Sub(b.lastFill).Seconds()
+		b.tokens += elapsed * s.Rate
+		if b.tokens > s.Burst {
+			b.tokens = s.Burst
+		}
+		b.lastFill = now
+	}
+
+	if b.tokens < 1 {
+		s.dropped[level]++
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// Dropped implements DroppedCounter, returning and resetting the per-level
+// drop counts accumulated since the last call.
+func (s *TokenBucketSampler) Dropped() map[Level]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[Level]int64, len(s.dropped))
+	for level, n := range s.dropped {
+		if n > 0 {
+			out[level] = n
+			s.dropped[level] = 0
+		}
+	}
+	return out
+}
+
+// KeySampler logs the first M records for a given key, then 1-in-N after
+// that, bounding memory with an LRU cache of keys.
+type KeySampler struct {
+	First   int // always log the first First records per key
+	Then    int // after First, emit 1 of every Then records
+	MaxKeys int // LRU capacity; 0 uses a default of 10000
+
+	mu    sync.Mutex
+	seen  map[string]int
+	order []string
+}
+
+// Allow implements Sampler.
+func (s *KeySampler) Allow(_ Level, key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seen == nil {
+		s.seen = make(map[string]int)
+	}
+
+	count, ok := s.seen[key]
+	if !ok {
+		s.order = append(s.order, key)
+		s.evictLocked()
+	} else {
+		// Move key to the back of order so eviction drops the least
+		// recently used key.
+		for i, k := range s.order {
+			if k == key {
+				copy(s.order[i:], s.order[i+1:])
+				s.order[len(s.order)-1] = key
+				break
+			}
+		}
+	}
+	count++
+	s.seen[key] = count
+
+	if count <= s.First {
+		return true
+	}
+	then := s.Then
+	if then <= 0 {
+		then = 1
+	}
+	return (count-s.First)%then == 0
+}
+
+func (s *KeySampler) evictLocked() {
+	max := s.MaxKeys
+	if max <= 0 {
+		max = 10000
+	}
+	for len(s.order) > max {
+		oldest := s.order[0]
+		s.order = s.order[1:]
+		delete(s.seen, oldest)
+	}
+}
+
+// SuppressedSince is implemented by samplers that can report how many
+// records at a (level, key) bucket were suppressed since the last one that
+// was allowed through, so the caller can attach that count to the record
+// it's about to emit (see BurstSampler).
+type SuppressedSince interface {
+	SuppressedSince(level Level, key string) int64
+}
+
+// SamplingOptions configures a BurstSampler.
+type SamplingOptions struct {
+	// InitialBurst is how many records per (level, message) bucket are
+	// always logged before rate limiting kicks in.
+	InitialBurst int
+	// ThereafterEvery emits 1 of every N records once InitialBurst is
+	// exhausted, so 1 logs every record. A value <= 0 logs nothing further
+	// until Window resets the bucket.
+	ThereafterEvery int
+	// Window is how long a (level, message) bucket's burst allowance lasts
+	// before resetting. A record for a key not seen within Window starts a
+	// fresh burst.
+	Window time.Duration
+	// RatePerSecond, when set for a level, additionally caps that level to
+	// a token-bucket rate regardless of the burst/every state.
+	RatePerSecond map[Level]float64
+	// Burst is the token-bucket capacity per level; used only with
+	// RatePerSecond.
+	Burst map[Level]float64
+	// SweepInterval is how often the background sweeper evicts buckets that
+	// have had no activity for Window. Defaults to Window, or 1 minute if
+	// Window is also zero.
+	SweepInterval time.Duration
+}
+
+type burstBucket struct {
+	windowStart time.Time
+	count       int64
+	suppressed  int64
+	lastSince   int64
+	lastSeen    time.Time
+}
+
+// BurstSampler implements a zerolog-style "log the first N, then every Mth"
+// sampler keyed by (level, message), with an optional per-level token-bucket
+// rate cap layered on top. A background goroutine sweeps buckets that have
+// been idle for longer than Window so memory doesn't grow with message
+// cardinality.
+type BurstSampler struct {
+	opts SamplingOptions
+
+	mu          sync.Mutex
+	buckets     map[uint64]*burstBucket
+	rateBuckets map[Level]*tokenBucketState
+
+	stopC chan struct{}
+	once  sync.Once
+}
+
+// NewBurstSampler creates a BurstSampler from opts and starts its background
+// sweeper. Callers that stop using a BurstSampler should call Close to stop
+// the sweeper goroutine.
+func NewBurstSampler(opts SamplingOptions) *BurstSampler {
+	sweep := opts.SweepInterval
+	if sweep <= 0 {
+		sweep = opts.Window
+	}
+	if sweep <= 0 {
+		sweep = time.Minute
+	}
+
+	s := &BurstSampler{
+		opts:    opts,
+		buckets: make(map[uint64]*burstBucket),
+		stopC:   make(chan struct{}),
+	}
+	if len(opts.RatePerSecond) > 0 {
+		s.rateBuckets = make(map[Level]*tokenBucketState)
+	}
+	go s.sweepLoop(sweep)
+	return s
+}
+
+func bucketKey(level Level, key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(strconv.Itoa(int(level))))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(key))
+	return h.Sum64()
+}
+
+// Allow implements Sampler.
+func (s *BurstSampler) Allow(level Level, key string) bool {
+	if rate, ok := s.opts.RatePerSecond[level]; ok {
+		if !s.allowRate(level, rate, s.opts.Burst[level]) {
+			return false
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	k := bucketKey(level, key)
+	b, ok := s.buckets[k]
+	if !ok || (s.opts.Window > 0 && now.Sub(b.windowStart) >= s.opts.Window) {
+		b = &burstBucket{windowStart: now}
+		s.buckets[k] = b
+	}
+	b.lastSeen = now
+	b.count++
+
+	if int(b.count) <= s.opts.InitialBurst {
+		b.lastSince = 0
+		return true
+	}
+
+	every := s.opts.ThereafterEvery
+	if every <= 0 {
+		b.suppressed++
+		return false
+	}
+	if (int(b.count)-s.opts.InitialBurst)%every == 0 {
+		b.lastSince = b.suppressed
+		b.suppressed = 0
+		return true
+	}
+	b.suppressed++
+	return false
+}
+
+func (s *BurstSampler) allowRate(level Level, rate, burst float64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	b, ok := s.rateBuckets[level]
+	if !ok {
+		b = &tokenBucketState{tokens: burst, lastFill: now}
+		s.rateBuckets[level] = b
+	} else {
+		elapsed := now.Sub(b.lastFill).Seconds()
+		b.tokens += elapsed * rate
+		if b.tokens > burst {
+			b.tokens = burst
+		}
+		b.lastFill = now
+	}
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// SuppressedSince implements SuppressedSince, returning how many records for
+// (level, key) were suppressed between the previous allowed record and the
+// one just allowed.
+func (s *BurstSampler) SuppressedSince(level Level, key string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.buckets[bucketKey(level, key)]
+	if !ok {
+		return 0
+	}
+	return b.lastSince
+}
+
+func (s *BurstSampler) sweepLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.sweep()
+		case <-s.stopC:
+			return
+		}
+	}
+}
+
+func (s *BurstSampler) sweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.opts.Window <= 0 {
+		return
+	}
+	now := time.Now()
+	for k, b := range s.buckets {
+		if now.Sub(b.lastSeen) >= s.opts.Window {
+			delete(s.buckets, k)
+		}
+	}
+}
+
+// Close stops the background sweeper goroutine.
+func (s *BurstSampler) Close() error {
+	s.once.Do(func() { close(s.stopC) })
+	return nil
+}
+
+// FlushSampling emits a "sampling.dropped" info record per level with a
+// non-zero drop count, for samplers that implement DroppedCounter. Callers
+// should invoke this periodically (e.g. from a ticker) to surface loss.
+func (l *Logger) FlushSampling() {
+	dc, ok := l.sampler.(DroppedCounter)
+	if !ok {
+		return
+	}
+	for level, n := range dc.Dropped() {
+		_ = l.Info("sampling.dropped", Map{"level": level.String(), "dropped": n})
+	}
+}
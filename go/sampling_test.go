@@ -0,0 +1,270 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateSamplerEmitsOneOfN(t *testing.T) {
+	s := &RateSampler{N: 3}
+	var allowed int
+	for i := 0; i < 9; i++ {
+		if s.Allow(LevelInfo, "") {
+			allowed++
+		}
+	}
+	if allowed != 3 {
+		t.Errorf("allowed = %d, want 3", allowed)
+	}
+}
+
+func TestRateSamplerConcurrent(t *testing.T) {
+	s := &RateSampler{N: 10}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if s.Allow(LevelInfo, "") {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if allowed != 10 {
+		t.Errorf("allowed = %d, want 10", allowed)
+	}
+}
+
+func TestTokenBucketSamplerCapsRate(t *testing.T) {
+	s := &TokenBucketSampler{Rate: 0, Burst: 5}
+	var allowed int
+	for i := 0; i < 20; i++ {
+		if s.Allow(LevelWarn, "") {
+			allowed++
+		}
+	}
+	if allowed != 5 {
+		t.Errorf("allowed = %d, want 5", allowed)
+	}
+
+	dropped := s.Dropped()
+	if dropped[LevelWarn] != 15 {
+		t.Errorf("dropped[warn] = %d, want 15", dropped[LevelWarn])
+	}
+
+	// Dropped() resets counters.
+	dropped = s.Dropped()
+	if len(dropped) != 0 {
+		t.Errorf("Dropped() after reset should be empty, got %v", dropped)
+	}
+}
+
+func TestKeySamplerFirstThenRatio(t *testing.T) {
+	s := &KeySampler{First: 2, Then: 3}
+	var allowed int
+	for i := 0; i < 11; i++ {
+		if s.Allow(LevelError, "boom") {
+			allowed++
+		}
+	}
+	// First 2 always allowed, then 1-in-3 of the remaining 9 => 3 more.
+	if allowed != 5 {
+		t.Errorf("allowed = %d, want 5", allowed)
+	}
+}
+
+func TestKeySamplerIsolatesKeys(t *testing.T) {
+	s := &KeySampler{First: 1, Then: 100}
+	if !s.Allow(LevelInfo, "a") {
+		t.Error("first record for key a should be allowed")
+	}
+	if !s.Allow(LevelInfo, "b") {
+		t.Error("first record for key b should be allowed")
+	}
+	if s.Allow(LevelInfo, "a") {
+		t.Error("second record for key a should be sampled out")
+	}
+}
+
+func TestKeySamplerEvictsOldestKeys(t *testing.T) {
+	s := &KeySampler{First: 1, Then: 1, MaxKeys: 2}
+	s.Allow(LevelInfo, "a")
+	s.Allow(LevelInfo, "b")
+	s.Allow(LevelInfo, "c")
+
+	if len(s.seen) != 2 {
+		t.Errorf("len(seen) = %d, want 2", len(s.seen))
+	}
+	if _, ok := s.seen["a"]; ok {
+		t.Error("oldest key 'a' should have been evicted")
+	}
+}
+
+func TestSamplerDropsRecordWithoutMutatingContext(t *testing.T) {
+	resetGlobalContext()
+	var buf bytes.Buffer
+	l := Default()
+	l.SetOutput(&buf)
+	l.prettyPrint = false
+	l.sampler = &RateSampler{N: 2}
+
+	l.SetCorrelationID("before-drop")
+	_ = l.Info("first") // allowed
+	buf.Reset()
+	_ = l.Info("second") // dropped
+
+	if buf.Len() != 0 {
+		t.Error("sampled-out record should not be emitted")
+	}
+	if l.CorrelationID() != "before-drop" {
+		t.Errorf("correlationId changed after dropped record: %q", l.CorrelationID())
+	}
+
+	l.AddContext(Map{"x": "y"})
+	ctx := l.Context()
+	nested, ok := ctx[KeyContext].(Map)
+	if !ok || nested["x"] != "y" {
+		t.Error("AddContext should still work after a dropped record")
+	}
+}
+
+func TestBurstSamplerInitialBurstThenEvery(t *testing.T) {
+	s := NewBurstSampler(SamplingOptions{InitialBurst: 2, ThereafterEvery: 3, Window: time.Hour})
+	defer s.Close()
+
+	var allowed int
+	for i := 0; i < 11; i++ {
+		if s.Allow(LevelError, "boom") {
+			allowed++
+		}
+	}
+	// First 2 always allowed, then 1-in-3 of the remaining 9 => 3 more.
+	if allowed != 5 {
+		t.Errorf("allowed = %d, want 5", allowed)
+	}
+}
+
+func TestBurstSamplerIsolatesByLevelAndKey(t *testing.T) {
+	s := NewBurstSampler(SamplingOptions{InitialBurst: 1, ThereafterEvery: 100, Window: time.Hour})
+	defer s.Close()
+
+	if !s.Allow(LevelInfo, "a") {
+		t.Error("first record for (info, a) should be allowed")
+	}
+	if !s.Allow(LevelWarn, "a") {
+		t.Error("first record for (warn, a) should be allowed even though (info, a) was already seen")
+	}
+	if s.Allow(LevelInfo, "a") {
+		t.Error("second record for (info, a) should be sampled out")
+	}
+}
+
+func TestBurstSamplerWindowResetsBurst(t *testing.T) {
+	s := NewBurstSampler(SamplingOptions{InitialBurst: 1, ThereafterEvery: 100, Window: 10 * time.Millisecond})
+	defer s.Close()
+
+	if !s.Allow(LevelInfo, "a") {
+		t.Fatal("first record should be allowed")
+	}
+	if s.Allow(LevelInfo, "a") {
+		t.Fatal("second record within the window should be sampled out")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if !s.Allow(LevelInfo, "a") {
+		t.Error("record after the window elapses should start a fresh burst")
+	}
+}
+
+func TestBurstSamplerRateCap(t *testing.T) {
+	s := NewBurstSampler(SamplingOptions{
+		InitialBurst:    1000, // burst alone wouldn't limit anything here
+		ThereafterEvery: 1,
+		Window:          time.Hour,
+		RatePerSecond:   map[Level]float64{LevelWarn: 0},
+		Burst:           map[Level]float64{LevelWarn: 5},
+	})
+	defer s.Close()
+
+	var allowed int
+	for i := 0; i < 20; i++ {
+		if s.Allow(LevelWarn, "x") {
+			allowed++
+		}
+	}
+	if allowed != 5 {
+		t.Errorf("allowed = %d, want 5 (capped by the zero-refill token bucket)", allowed)
+	}
+}
+
+func TestBurstSamplerSweepEvictsIdleBuckets(t *testing.T) {
+	s := NewBurstSampler(SamplingOptions{InitialBurst: 1, Window: 5 * time.Millisecond, SweepInterval: 5 * time.Millisecond})
+	defer s.Close()
+
+	s.Allow(LevelInfo, "a")
+	s.mu.Lock()
+	created := len(s.buckets)
+	s.mu.Unlock()
+	if created == 0 {
+		t.Fatal("expected a bucket to be created")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	s.mu.Lock()
+	n := len(s.buckets)
+	s.mu.Unlock()
+	if n != 0 {
+		t.Errorf("len(buckets) = %d, want 0 after the sweeper evicts idle buckets", n)
+	}
+}
+
+func TestLoggerAttachesSampledSince(t *testing.T) {
+	resetGlobalContext()
+	var buf bytes.Buffer
+	l := Default()
+	l.SetOutput(&buf)
+	l.prettyPrint = false
+	l.sampler = NewBurstSampler(SamplingOptions{InitialBurst: 1, ThereafterEvery: 2, Window: time.Hour})
+	defer l.sampler.(*BurstSampler).Close()
+
+	_ = l.Info("flood") // allowed (burst)
+	buf.Reset()
+	_ = l.Info("flood") // suppressed
+	_ = l.Info("flood") // allowed (1-in-2), should report sampled_since
+
+	var payload map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	if payload[KeySampledSince] == nil {
+		t.Error("expected sampled_since to be set on the record following suppressed ones")
+	}
+}
+
+func TestFlushSamplingEmitsDroppedCounts(t *testing.T) {
+	resetGlobalContext()
+	var buf bytes.Buffer
+	l := Default()
+	l.SetOutput(&buf)
+	l.prettyPrint = false
+	l.sampler = &TokenBucketSampler{Rate: 0, Burst: 1}
+
+	_ = l.Warn("one")
+	_ = l.Warn("two") // dropped, counted
+
+	buf.Reset()
+	l.FlushSampling()
+
+	if buf.Len() == 0 {
+		t.Error("FlushSampling should emit a sampling.dropped record")
+	}
+}
@@ -0,0 +1,332 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sink is a pluggable output destination for log records. A Logger owns a
+// slice of Sinks and dispatches every record that passes level filtering and
+// sampling to each one, after the record has been rendered (JSON or
+// pretty-printed ANSI, per the logger's PrettyPrint setting) and passed
+// through any ContextFilter/Hooks.
+type Sink interface {
+	// Write delivers one record. level and payload are the structured form;
+	// rendered is the logger's already-formatted bytes (what stdout/file
+	// output would receive), provided so simple sinks don't need to
+	// re-marshal.
+	Write(level Level, payload Map, rendered []byte) error
+	Close() error
+}
+
+// AddSink registers an additional Sink. Sinks fire in registration order,
+// after the logger's default sinks (if any were kept).
+func (l *Logger) AddSink(s Sink) {
+	l.sinks = append(l.sinks, s)
+}
+
+// StdoutSink writes rendered records to an io.Writer (os.Stdout by default),
+// optionally queued through an AsyncWriter so callers never block on I/O.
+type StdoutSink struct {
+	MinLevel Level
+	Writer   io.Writer
+
+	async *AsyncWriter
+}
+
+// NewStdoutSink creates a StdoutSink writing to os.Stdout. Pass async to
+// queue writes through an AsyncWriter instead of writing synchronously.
+func NewStdoutSink(minLevel Level, async *AsyncWriterOptions) *StdoutSink {
+	s := &StdoutSink{MinLevel: minLevel, Writer: os.Stdout}
+	if async != nil {
+		s.async = NewAsyncWriter(s.Writer, *async)
+	}
+	return s
+}
+
+// Write implements Sink.
+func (s *StdoutSink) Write(level Level, _ Map, rendered []byte) error {
+	if level < s.MinLevel {
+		return nil
+	}
+	if s.async != nil {
+		if level >= LevelFatal {
+			s.async.Flush()
+			_, err := s.Writer.Write(rendered)
+			return err
+		}
+		_, err := s.async.Write(rendered)
+		return err
+	}
+	_, err := s.Writer.Write(rendered)
+	return err
+}
+
+// Close implements Sink.
+func (s *StdoutSink) Close() error {
+	if s.async != nil {
+		return s.async.Close()
+	}
+	return nil
+}
+
+// Dropped returns the number of records this sink's AsyncWriter has dropped
+// due to backpressure, or 0 if the sink isn't async.
+func (s *StdoutSink) Dropped() int64 {
+	if s.async == nil {
+		return 0
+	}
+	return s.async.Dropped()
+}
+
+// FileSink writes rendered records to a rotating log file.
+type FileSink struct {
+	MinLevel Level
+
+	writer *rotatingWriter
+	async  *AsyncWriter
+}
+
+// NewFileSink creates a FileSink backed by a rotating log file configured by
+// rotation. Pass async to queue writes through an AsyncWriter.
+func NewFileSink(rotation RotationOptions, minLevel Level, async *AsyncWriterOptions) (*FileSink, error) {
+	rw, err := newRotatingWriter(rotation)
+	if err != nil {
+		return nil, fmt.Errorf("init file sink: %w", err)
+	}
+	s := &FileSink{MinLevel: minLevel, writer: rw}
+	if async != nil {
+		s.async = NewAsyncWriter(rw, *async)
+	}
+	return s, nil
+}
+
+// Write implements Sink.
+func (s *FileSink) Write(level Level, _ Map, rendered []byte) error {
+	if level < s.MinLevel {
+		return nil
+	}
+	if s.async != nil {
+		if level >= LevelFatal {
+			s.async.Flush()
+			return s.writer.write(rendered)
+		}
+		_, err := s.async.Write(rendered)
+		return err
+	}
+	return s.writer.write(rendered)
+}
+
+// Close implements Sink.
+func (s *FileSink) Close() error {
+	if s.async != nil {
+		if err := s.async.Close(); err != nil {
+			return err
+		}
+	}
+	return s.writer.close()
+}
+
+// Dropped returns the number of records this sink's AsyncWriter has dropped
+// due to backpressure, or 0 if the sink isn't async.
+func (s *FileSink) Dropped() int64 {
+	if s.async == nil {
+		return 0
+	}
+	return s.async.Dropped()
+}
+
+// syslogSeverity maps a Level to its closest RFC 5424 severity: trace/debug
+// -> DEBUG(7), info -> INFO(6), warn -> WARNING(4), error -> ERR(3),
+// fatal -> CRIT(2).
+func syslogSeverity(level Level) int {
+	switch {
+	case level >= LevelFatal:
+		return 2
+	case level >= LevelError:
+		return 3
+	case level >= LevelWarn:
+		return 4
+	case level >= LevelInfo:
+		return 6
+	default:
+		return 7
+	}
+}
+
+// SyslogSink sends RFC 5424 formatted records to a syslog server over UDP,
+// TCP, or a UNIX domain socket. For logging to the local syslog daemon via
+// the standard log/syslog package instead, see SyslogHook in hooks.go.
+type SyslogSink struct {
+	MinLevel Level
+	Facility int // syslog facility number, e.g. 1 = user-level (default)
+	Hostname string
+	AppName  string
+
+	conn net.Conn
+}
+
+// NewSyslogSink dials a syslog server at addr over network ("udp", "tcp", or
+// "unix") and returns a SyslogSink that forwards records at minLevel and
+// above.
+func NewSyslogSink(network, addr string, minLevel Level) (*SyslogSink, error) {
+	conn, err := net.Dial(network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("dial syslog: %w", err)
+	}
+
+	hostname, _ := os.Hostname()
+	return &SyslogSink{
+		MinLevel: minLevel,
+		Facility: 1,
+		Hostname: hostname,
+		AppName:  "logger",
+		conn:     conn,
+	}, nil
+}
+
+// Write implements Sink.
+func (s *SyslogSink) Write(level Level, payload Map, rendered []byte) error {
+	if level < s.MinLevel {
+		return nil
+	}
+
+	msg, _ := payload[KeyMessage].(string)
+	if msg == "" {
+		msg = strings.TrimRight(string(rendered), "\n")
+	}
+
+	priority := s.Facility*8 + syslogSeverity(level)
+	frame := fmt.Sprintf("<%d>1 %s %s %s - - - %s\n",
+		priority,
+		time.Now().UTC().Format(time.RFC3339),
+		orDash(s.Hostname),
+		orDash(s.AppName),
+		msg,
+	)
+
+	_, err := s.conn.Write([]byte(frame))
+	return err
+}
+
+// Close implements Sink.
+func (s *SyslogSink) Close() error { return s.conn.Close() }
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// JournaldSink sends records to systemd-journald's native socket protocol:
+// newline-delimited "KEY=value" pairs per datagram, with the structured
+// payload's fields forwarded alongside PRIORITY and MESSAGE.
+type JournaldSink struct {
+	MinLevel Level
+
+	conn *net.UnixConn
+}
+
+// NewJournaldSink dials the local journald native socket
+// (/run/systemd/journal/socket) and returns a JournaldSink that forwards
+// records at minLevel and above.
+func NewJournaldSink(minLevel Level) (*JournaldSink, error) {
+	addr := &net.UnixAddr{Name: "/run/systemd/journal/socket", Net: "unixgram"}
+	conn, err := net.DialUnix("unixgram", nil, addr)
+	if err != nil {
+		return nil, fmt.Errorf("dial journald socket: %w", err)
+	}
+	return &JournaldSink{MinLevel: minLevel, conn: conn}, nil
+}
+
+// Write implements Sink.
+func (s *JournaldSink) Write(level Level, payload Map, rendered []byte) error {
+	if level < s.MinLevel {
+		return nil
+	}
+
+	msg, _ := payload[KeyMessage].(string)
+	if msg == "" {
+		msg = strings.TrimRight(string(rendered), "\n")
+	}
+
+	var buf bytes.Buffer
+	writeJournaldField(&buf, "PRIORITY", strconv.Itoa(syslogSeverity(level)))
+	writeJournaldField(&buf, "MESSAGE", msg)
+	for key, val := range payload {
+		if key == KeyMessage {
+			continue
+		}
+		field := journaldFieldName(key)
+		if field == "" {
+			continue
+		}
+		writeJournaldField(&buf, field, fmt.Sprintf("%v", val))
+	}
+
+	_, err := s.conn.Write(buf.Bytes())
+	return err
+}
+
+// Close implements Sink.
+func (s *JournaldSink) Close() error { return s.conn.Close() }
+
+// writeJournaldField appends one field in sd_journal's native wire format: a
+// plain "KEY=value\n" line, or for values containing a newline, "KEY\n"
+// followed by an 8-byte little-endian length, the raw value, and a trailing
+// newline (the binary-safe framing the protocol requires for multi-line
+// values).
+func writeJournaldField(buf *bytes.Buffer, key, value string) {
+	if !strings.Contains(value, "\n") {
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+		return
+	}
+
+	buf.WriteString(key)
+	buf.WriteByte('\n')
+	var lenBuf [8]byte
+	binary.LittleEndian.PutUint64(lenBuf[:], uint64(len(value)))
+	buf.Write(lenBuf[:])
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journaldFieldName converts a payload key to a valid journald field name:
+// uppercase ASCII letters, digits, and underscores, not starting with a
+// digit. camelCase boundaries (e.g. "correlationId") get an underscore
+// inserted so they stay readable once uppercased. Keys that reduce to
+// nothing usable are skipped.
+func journaldFieldName(key string) string {
+	var sb strings.Builder
+	var prev rune
+	for i, r := range key {
+		if i > 0 && r >= 'A' && r <= 'Z' && prev != '_' && !(prev >= 'A' && prev <= 'Z') {
+			sb.WriteByte('_')
+		}
+		switch {
+		case r >= 'a' && r <= 'z':
+			sb.WriteRune(r - ('a' - 'A'))
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte('_')
+		}
+		prev = r
+	}
+	name := sb.String()
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,186 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStdoutSinkRespectsMinLevel(t *testing.T) {
+	var buf bytes.Buffer
+	s := &StdoutSink{MinLevel: LevelWarn, Writer: &buf}
+
+	_ = s.Write(LevelInfo, Map{}, []byte("ignored\n"))
+	if buf.Len() != 0 {
+		t.Error("record below MinLevel should not reach the writer")
+	}
+
+	_ = s.Write(LevelWarn, Map{}, []byte("kept\n"))
+	if buf.Len() == 0 {
+		t.Error("record at MinLevel should reach the writer")
+	}
+}
+
+func TestAddSinkReceivesRecords(t *testing.T) {
+	resetGlobalContext()
+	var buf bytes.Buffer
+	l := Default()
+	l.SetOutput(&bytes.Buffer{}) // silence the default stdout sink for this test
+	l.AddSink(&StdoutSink{Writer: &buf})
+
+	_ = l.Info("hello")
+
+	if buf.Len() == 0 {
+		t.Error("custom sink added via AddSink should receive the record")
+	}
+}
+
+func TestOptionsSinksReplacesDefaults(t *testing.T) {
+	resetGlobalContext()
+	var buf bytes.Buffer
+	l, err := New(Options{Sinks: []Sink{&StdoutSink{Writer: &buf}}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	l.prettyPrint = false
+
+	_ = l.Info("hello")
+
+	if buf.Len() == 0 {
+		t.Error("custom Options.Sinks should be used in place of the defaults")
+	}
+}
+
+type failingSink struct {
+	err error
+}
+
+func (s *failingSink) Write(_ Level, _ Map, _ []byte) error { return s.err }
+func (s *failingSink) Close() error                         { return nil }
+
+func TestFailingSinkDoesNotBlockLaterSinks(t *testing.T) {
+	resetGlobalContext()
+	var buf bytes.Buffer
+	l, err := New(Options{Sinks: []Sink{
+		&failingSink{err: errors.New("network unreachable")},
+		&StdoutSink{Writer: &buf},
+	}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	l.prettyPrint = false
+
+	emitErr := l.Info("hello")
+
+	if buf.Len() == 0 {
+		t.Error("sink after a failing one should still receive the record")
+	}
+	if emitErr == nil || !strings.Contains(emitErr.Error(), "network unreachable") {
+		t.Errorf("Info() = %v, want an error mentioning the failing sink", emitErr)
+	}
+}
+
+func TestStdoutSinkFatalBypassesAsyncQueue(t *testing.T) {
+	var buf syncBuffer
+	s := &StdoutSink{Writer: &buf, async: NewAsyncWriter(&buf, AsyncWriterOptions{BufferSize: 16, FlushInterval: time.Hour})}
+
+	// Queue an Info record that would otherwise sit unflushed for an hour,
+	// then a Fatal record that must reach the writer immediately.
+	_ = s.Write(LevelInfo, Map{}, []byte("queued\n"))
+	_ = s.Write(LevelFatal, Map{}, []byte("fatal\n"))
+
+	if !strings.Contains(buf.String(), "fatal") {
+		t.Error("Fatal should flush the async queue and write synchronously")
+	}
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestFileSinkWritesToRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	sink, err := NewFileSink(RotationOptions{
+		Path:           dir,
+		FilenamePrefix: "test",
+		Extension:      "log",
+		Size:           1048576,
+		Interval:       Duration(24 * time.Hour),
+		MaxFiles:       5,
+	}, 0, nil)
+	if err != nil {
+		t.Fatalf("NewFileSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	if err := sink.Write(LevelInfo, Map{}, []byte("line one\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	data, err := os.ReadFile(sink.writer.currentPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if !strings.Contains(string(data), "line one") {
+		t.Error("expected the written line to appear in the rotated file")
+	}
+}
+
+func TestSyslogSeverityMapping(t *testing.T) {
+	tests := []struct {
+		level Level
+		want  int
+	}{
+		{LevelTrace, 7},
+		{LevelDebug, 7},
+		{LevelInfo, 6},
+		{LevelWarn, 4},
+		{LevelError, 3},
+		{LevelFatal, 2},
+	}
+	for _, tt := range tests {
+		if got := syslogSeverity(tt.level); got != tt.want {
+			t.Errorf("syslogSeverity(%v) = %d, want %d", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestJournaldFieldName(t *testing.T) {
+	tests := map[string]string{
+		"msg":           "MSG",
+		"correlationId": "CORRELATION_ID",
+		"":              "",
+		"1leading":      "",
+	}
+	for in, want := range tests {
+		if got := journaldFieldName(in); got != want {
+			t.Errorf("journaldFieldName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWriteJournaldFieldSingleLine(t *testing.T) {
+	var buf bytes.Buffer
+	writeJournaldField(&buf, "MESSAGE", "hello world")
+	if buf.String() != "MESSAGE=hello world\n" {
+		t.Errorf("got %q", buf.String())
+	}
+}
+
+func TestWriteJournaldFieldMultiLineUsesBinaryFraming(t *testing.T) {
+	var buf bytes.Buffer
+	writeJournaldField(&buf, "MESSAGE", "line one\nline two")
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "MESSAGE\n") {
+		t.Fatalf("expected the binary-safe framing to start with the key line, got %q", out)
+	}
+	if !strings.Contains(out, "line one\nline two") {
+		t.Error("expected the raw multi-line value to be present")
+	}
+}
@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+)
+
+// traceparentRe matches a W3C traceparent header value:
+// version-traceid-parentid-flags, e.g. 00-<32 hex>-<16 hex>-01.
+// See https://www.w3.org/TR/trace-context/#traceparent-header.
+var traceparentRe = regexp.MustCompile(`^[0-9a-f]{2}-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}$`)
+
+// parseTraceparent extracts the trace ID and parent (span) ID from a W3C
+// traceparent header value.
+func parseTraceparent(value string) (traceID, spanID string, ok bool) {
+	m := traceparentRe.FindStringSubmatch(value)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+// TraceExtractor extracts a trace ID and span ID from a context.Context
+// carrying tracing metadata (e.g. an active OpenTelemetry span). Register
+// one via SetTraceExtractor to wire Logger.WithContext up to your tracing
+// system without this package depending on it directly.
+type TraceExtractor func(ctx context.Context) (traceID, spanID string, ok bool)
+
+var traceExtractor TraceExtractor
+
+// SetTraceExtractor installs the context.Context trace extractor used by
+// Logger.WithContext. Passing nil disables extraction.
+func SetTraceExtractor(fn TraceExtractor) { traceExtractor = fn }
+
+// applyTraceparent sets the trace/span IDs parsed from a W3C traceparent
+// header on the logger's base context, so services behind an API Gateway
+// that propagates traceparent get automatic correlation.
+func (l *Logger) applyTraceparent(value string) {
+	traceID, spanID, ok := parseTraceparent(value)
+	if !ok {
+		return
+	}
+	l.AddBaseContextKey(KeyTraceID, traceID)
+	l.AddBaseContextKey(KeySpanID, spanID)
+}
+
+// loggerCtxKey is the context.Context key used by ContextWithLogger.
+type loggerCtxKey struct{}
+
+// ContextWithLogger returns a copy of ctx carrying l, retrievable later via
+// LoggerFromContext. Use this to thread a request-scoped Logger (e.g. one
+// returned by WithContext) through call chains that accept a
+// context.Context but not a *Logger.
+func ContextWithLogger(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, l)
+}
+
+// LoggerFromContext returns the Logger attached to ctx via
+// ContextWithLogger, or Default() if ctx carries none.
+func LoggerFromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(loggerCtxKey{}).(*Logger); ok {
+		return l
+	}
+	return Default()
+}
+
+// FromRequest returns the Logger attached to r's context via
+// ContextWithLogger, or Default() if r carries none. Middleware that scopes
+// a Logger per request (see the httpmw subpackage) stores it here so
+// handlers can retrieve it without threading it through explicitly.
+func FromRequest(r *http.Request) *Logger {
+	return LoggerFromContext(r.Context())
+}
+
+// LogAttrs logs msg at level, extracting trace/span IDs from ctx (via the
+// registered TraceExtractor, see SetTraceExtractor) for this call only. It
+// does not mutate the shared global context or require a WithContext clone,
+// so concurrent request handlers sharing a single Logger can still attach
+// the right trace/span IDs to each record.
+func (l *Logger) LogAttrs(ctx context.Context, level Level, msg string, args ...any) error {
+	if !l.isEnabled(level) || !l.sampled(level, msg) {
+		return nil
+	}
+
+	payload := l.withSampledSince(level, msg, l.buildLogObject(level, msg, args))
+
+	if traceExtractor != nil {
+		if traceID, spanID, ok := traceExtractor(ctx); ok {
+			if traceID != "" {
+				payload[KeyTraceID] = traceID
+			}
+			if spanID != "" {
+				payload[KeySpanID] = spanID
+			}
+		}
+	}
+
+	return l.emit(level, payload)
+}
@@ -0,0 +1,142 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	traceID, spanID, ok := parseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if !ok {
+		t.Fatal("expected a valid traceparent to parse")
+	}
+	if traceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("traceID = %q, want %q", traceID, "4bf92f3577b34da6a3ce929d0e0e4736")
+	}
+	if spanID != "00f067aa0ba902b7" {
+		t.Errorf("spanID = %q, want %q", spanID, "00f067aa0ba902b7")
+	}
+}
+
+func TestParseTraceparentInvalid(t *testing.T) {
+	_, _, ok := parseTraceparent("not-a-traceparent")
+	if ok {
+		t.Error("malformed traceparent should not parse")
+	}
+}
+
+func TestAddHTTPRequestParsesTraceparent(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+
+	l.AddHTTPRequest(HTTPRequest{
+		Method: "GET",
+		Path:   "/",
+		Headers: map[string]string{
+			"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		},
+	})
+
+	ctx := l.Context()
+	if ctx[KeyTraceID] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("traceId = %v, want %q", ctx[KeyTraceID], "4bf92f3577b34da6a3ce929d0e0e4736")
+	}
+	if ctx[KeySpanID] != "00f067aa0ba902b7" {
+		t.Errorf("spanId = %v, want %q", ctx[KeySpanID], "00f067aa0ba902b7")
+	}
+}
+
+func TestWithContextDoesNotMutateGlobalContext(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+
+	SetTraceExtractor(func(ctx context.Context) (string, string, bool) {
+		return "trace-from-ctx", "span-from-ctx", true
+	})
+	defer SetTraceExtractor(nil)
+
+	scoped := l.WithContext(context.Background())
+
+	payload := scoped.buildLogObject(LevelInfo, "hi", nil)
+	if payload[KeyTraceID] != "trace-from-ctx" {
+		t.Errorf("traceId = %v, want %q", payload[KeyTraceID], "trace-from-ctx")
+	}
+	if payload[KeySpanID] != "span-from-ctx" {
+		t.Errorf("spanId = %v, want %q", payload[KeySpanID], "span-from-ctx")
+	}
+
+	// The shared global context must be untouched.
+	globalPayload := l.buildLogObject(LevelInfo, "hi", nil)
+	if globalPayload[KeySpanID] != nil {
+		t.Error("base logger should not pick up the scoped span ID")
+	}
+}
+
+func TestWithContextNoExtractorRegistered(t *testing.T) {
+	resetGlobalContext()
+	SetTraceExtractor(nil)
+	l := Default()
+
+	scoped := l.WithContext(context.Background())
+	if scoped.extra != nil {
+		t.Error("extra should be nil when no TraceExtractor is registered")
+	}
+}
+
+func TestContextWithLoggerRoundTrips(t *testing.T) {
+	resetGlobalContext()
+	l := Default()
+	l.SetName("Scoped")
+
+	ctx := ContextWithLogger(context.Background(), l)
+	got := LoggerFromContext(ctx)
+	if got != l {
+		t.Error("LoggerFromContext should return the Logger stored via ContextWithLogger")
+	}
+}
+
+func TestLoggerFromContextDefaultsWhenUnset(t *testing.T) {
+	got := LoggerFromContext(context.Background())
+	if got == nil {
+		t.Fatal("LoggerFromContext should fall back to Default() when ctx carries no Logger")
+	}
+}
+
+func TestLogAttrsUsesPerCallTraceContext(t *testing.T) {
+	resetGlobalContext()
+	var buf bytes.Buffer
+	l := Default()
+	l.SetOutput(&buf)
+	l.prettyPrint = false
+
+	SetTraceExtractor(func(ctx context.Context) (string, string, bool) {
+		return "trace-from-ctx", "span-from-ctx", true
+	})
+	defer SetTraceExtractor(nil)
+
+	_ = l.LogAttrs(context.Background(), LevelInfo, "hi")
+
+	var payload map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	if payload[KeyTraceID] != "trace-from-ctx" {
+		t.Errorf("traceId = %v, want %q", payload[KeyTraceID], "trace-from-ctx")
+	}
+	if payload[KeySpanID] != "span-from-ctx" {
+		t.Errorf("spanId = %v, want %q", payload[KeySpanID], "span-from-ctx")
+	}
+
+	// The shared global context must be untouched.
+	buf.Reset()
+	_ = l.Info("again")
+	var globalPayload map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &globalPayload); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	if globalPayload[KeySpanID] != nil {
+		t.Error("LogAttrs should not mutate the shared global context")
+	}
+}